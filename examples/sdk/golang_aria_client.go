@@ -2,22 +2,41 @@ package main
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"crypto/tls"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"io"
 	"log"
+	"math"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	
+	"gopkg.in/yaml.v3"
 )
 
 const HighUtilizationThreshold = 80.0
 
+// ClientVersion is the version reported in the default User-Agent
+const ClientVersion = "1.0.0"
+
+// DefaultUserAgent identifies this client in Aria access logs
+const DefaultUserAgent = "vmware-aria-go-client/" + ClientVersion
+
 // sanitizeLogInput removes potentially dangerous characters from log inputs
 func sanitizeLogInput(input string) string {
 	// Remove newlines, carriage returns, and other control characters to prevent log injection
@@ -25,7 +44,54 @@ func sanitizeLogInput(input string) string {
 	return re.ReplaceAllString(input, "_")
 }
 
-// AriaClient represents a client for VMware Aria Suite APIs
+// sensitiveFieldPattern matches credential and token fields in JSON or form-encoded payloads.
+// JSON values may be strings containing escaped quotes or non-string literals such as numbers.
+var sensitiveFieldPattern = regexp.MustCompile(`(?i)("(?:password|token|refresh_token|cspAuthToken|access_token)"\s*:\s*)(?:"(?:[^"\\]|\\.)*"|[^,}\]\s]+)|\b(password|token|refresh_token|cspAuthToken|access_token)=[^&\s]*`)
+
+// redactSecrets replaces the values of known sensitive fields with a placeholder
+func redactSecrets(input string) string {
+	return sensitiveFieldPattern.ReplaceAllStringFunc(input, func(match string) string {
+		if strings.HasPrefix(match, `"`) {
+			return sensitiveFieldPattern.ReplaceAllString(match, `$1"[REDACTED]"`)
+		}
+		return sensitiveFieldPattern.ReplaceAllString(match, `$2=[REDACTED]`)
+	})
+}
+
+// redactSecret replaces every occurrence of secret in body with a placeholder, including
+// the JSON-escaped and URL-encoded forms in which a server may echo it back
+func redactSecret(body []byte, secret string) []byte {
+	forms := []string{secret, url.QueryEscape(secret)}
+	if escaped, err := json.Marshal(secret); err == nil {
+		forms = append(forms, string(escaped[1:len(escaped)-1]))
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(secret); err == nil {
+		escaped := strings.TrimSpace(buf.String())
+		forms = append(forms, escaped[1:len(escaped)-1])
+	}
+	// Longer forms first, so an escaped form is not broken up by replacing the raw secret inside it
+	sort.Slice(forms, func(i, j int) bool { return len(forms[i]) > len(forms[j]) })
+	for _, form := range forms {
+		if form != "" {
+			body = bytes.ReplaceAll(body, []byte(form), []byte("[REDACTED]"))
+		}
+	}
+	return body
+}
+
+// sanitizeResponseBody prepares a response body for inclusion in errors and logs
+// by redacting secrets and removing control characters
+func sanitizeResponseBody(body []byte) string {
+	return sanitizeLogInput(redactSecrets(string(body)))
+}
+
+// AriaClient represents a client for VMware Aria Suite APIs.
+// A client is safe for concurrent use by multiple goroutines once constructed; the
+// auth token is guarded by a mutex, so when sharing a client read and replace it via
+// Token and SetAuthToken rather than the AuthToken field.
 type AriaClient struct {
 	BaseURL    string
 	Username   string
@@ -33,6 +99,571 @@ type AriaClient struct {
 	AuthToken  string
 	HTTPClient *http.Client
 	Logger     *log.Logger
+	AuthScheme string // Authorization scheme; AuthSchemeOpsToken for Operations, AuthSchemeBearer for Automation and Logs
+	ManualAuth bool   // When set, requests never call Authenticate implicitly; see ErrNotAuthenticated
+	
+	// SuiteAPIBasePath is the path prefix of the Operations Suite API, DefaultSuiteAPIBasePath
+	// unless a gateway in front of Aria rewrites it
+	SuiteAPIBasePath string
+	
+	// OrgID is the Automation organization to work in. When set it is sent as orgId in the
+	// token exchange, so the token is issued for that organization, and as the orgId query
+	// parameter of the org-scoped Automation endpoints (see orgScopedPrefixes). Blueprints and
+	// deployments reporting another orgId are still rejected. See GetOrganizations.
+	OrgID string
+	
+	resourceCache  *resourceCache
+	authRetries    int
+	authMaxBackoff time.Duration
+	backoff        Backoff
+	
+	maxResponseBytes int64
+	userAgent        string
+	
+	requestInterceptors  []RequestInterceptor
+	responseInterceptors []ResponseInterceptor
+	metricCategories     []MetricCategory
+	kindValidation       *resourceKindValidator
+	rawMetricsLimit      int
+	reportLocation       *time.Location
+	compressExports      bool
+	maxResourcesAnalyzed int
+	byteUnit             string
+	breaker              *circuitBreaker
+	retryObserver        func(RetryStats)
+	externalHTTPClient   bool
+	progress             ProgressFunc
+	compactJSON          bool
+	keepDuplicates       bool
+	labelAdapterKind     string
+	metricLabels         metricLabelCache
+	progressMu           sync.Mutex
+	rejectRedirects      bool
+	pool                 ConnectionPool
+	clock                Clock
+	logInsightURL        string
+	
+	logInsightMu    sync.Mutex
+	logInsightToken string
+	
+	lifecycleMu sync.Mutex
+	closed      bool
+	inFlight    map[uint64]context.CancelFunc
+	nextID      uint64
+	stopRefresh context.CancelFunc
+	
+	tokenMu     sync.RWMutex
+	tokenExpiry time.Time
+	clockSkew   time.Duration
+	
+	authMu     sync.Mutex
+	authFlight *authFlight
+}
+
+// authFlight is an authentication in progress whose result concurrent callers share
+type authFlight struct {
+	done    chan struct{}
+	err     Success
+	retries int
+}
+
+// ErrClientClosed is returned by requests made after Close or CloseNow
+var ErrClientClosed = errors.New("aria client is closed")
+
+// RequestInterceptor is invoked on every authenticated request before it is sent.
+// Returning an error aborts the request.
+type RequestInterceptor func(*http.Request) Success
+
+// ResponseInterceptor is invoked on every authenticated response before it is returned.
+// Returning an error closes the response and fails the request.
+type ResponseInterceptor func(*http.Response) Success
+
+// Authentication failure classes returned (wrapped) by Authenticate
+var (
+	ErrAuthUnreachable     = errors.New("authentication server unreachable")
+	ErrCredentialsRejected = errors.New("credentials rejected")
+)
+
+// ErrNotAuthenticated is returned when ManualAuth is set and a request has no valid token
+var ErrNotAuthenticated = errors.New("not authenticated")
+
+// ErrResponseTooLarge is returned when reading a response body exceeds the configured limit
+var ErrResponseTooLarge = errors.New("response body exceeds maximum allowed size")
+
+// DefaultMaxResponseBytes is the default cap on response body size (50 MB)
+const DefaultMaxResponseBytes = 50 << 20
+
+// Authorization schemes used by the Aria products
+const (
+	AuthSchemeOpsToken = "vRealizeOpsToken"
+	AuthSchemeBearer   = "Bearer"
+)
+
+// ClientOption configures optional AriaClient behaviour
+type ClientOption func(*AriaClient)
+
+// WithAuthScheme sets the Authorization scheme sent with authenticated requests
+func WithAuthScheme(scheme string) ClientOption {
+	return func(c *AriaClient) {
+		c.AuthScheme = scheme
+	}
+}
+
+// WithAuthRetries sets how many times Authenticate retries connection errors and 5xx
+// responses, doubling the delay from one second up to maxBackoff between attempts
+// unless WithBackoff replaces the curve
+func WithAuthRetries(retries int, maxBackoff time.Duration) ClientOption {
+	return func(c *AriaClient) {
+		c.authRetries = retries
+		c.authMaxBackoff = maxBackoff
+	}
+}
+
+// Backoff decides how long to wait before a retry. attempt counts the retries made so
+// far, starting at 0 for the first retry.
+type Backoff interface {
+	NextDelay(attempt int) time.Duration
+}
+
+// BackoffFunc adapts an ordinary function to the Backoff interface, e.g.
+// BackoffFunc(func(int) time.Duration { return 0 }) to retry immediately in tests
+type BackoffFunc func(attempt int) time.Duration
+
+// NextDelay calls f(attempt)
+func (f BackoffFunc) NextDelay(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// DefaultBackoffJitter is the fraction of each delay randomized by the default backoff
+const DefaultBackoffJitter = 0.2
+
+// ExponentialBackoff doubles the delay from Base on every attempt up to Max, then
+// subtracts a random share of up to Jitter (0 to 1) of it so that clients retrying
+// at the same time spread out
+type ExponentialBackoff struct {
+	Base   time.Duration
+	Max    time.Duration
+	Jitter float64
+}
+
+// NextDelay implements Backoff
+func (b ExponentialBackoff) NextDelay(attempt int) time.Duration {
+	delay := b.Base << attempt
+	if b.Max > 0 && (delay > b.Max || delay <= 0 || attempt >= 63) {
+		delay = b.Max
+	}
+	if b.Jitter > 0 && delay > 0 {
+		delay -= time.Duration(rand.Float64() * b.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// WithBackoff replaces the retry curve used between authentication retries and between
+// re-authentications after a 401. The default is ExponentialBackoff with
+// DefaultBackoffJitter, capped at the maxBackoff of WithAuthRetries.
+func WithBackoff(backoff Backoff) ClientOption {
+	return func(c *AriaClient) {
+		c.backoff = backoff
+	}
+}
+
+// retryBackoff returns the configured backoff, or the default exponential curve starting at base
+func (c *AriaClient) retryBackoff(base time.Duration) Backoff {
+	if c.backoff != nil {
+		return c.backoff
+	}
+	return ExponentialBackoff{Base: base, Max: c.authMaxBackoff, Jitter: DefaultBackoffJitter}
+}
+
+// WithMaxResponseBytes caps how many bytes of any response body are read
+// (DefaultMaxResponseBytes when unset) to protect against memory exhaustion
+func WithMaxResponseBytes(limit int64) ClientOption {
+	return func(c *AriaClient) {
+		c.maxResponseBytes = limit
+	}
+}
+
+// WithUserAgent replaces the User-Agent sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *AriaClient) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithUserAgentSuffix appends an application identifier to the User-Agent,
+// e.g. "vmware-aria-go-client/1.0.0 capacity-reporter/2.3"
+func WithUserAgentSuffix(suffix string) ClientOption {
+	return func(c *AriaClient) {
+		if c.userAgent == "" {
+			c.userAgent = DefaultUserAgent
+		}
+		c.userAgent += " " + suffix
+	}
+}
+
+// WithRequestInterceptor appends request interceptors, run in the order they are added
+func WithRequestInterceptor(interceptors ...RequestInterceptor) ClientOption {
+	return func(c *AriaClient) {
+		c.requestInterceptors = append(c.requestInterceptors, interceptors...)
+	}
+}
+
+// WithResponseInterceptor appends response interceptors, run in the order they are added
+func WithResponseInterceptor(interceptors ...ResponseInterceptor) ClientOption {
+	return func(c *AriaClient) {
+		c.responseInterceptors = append(c.responseInterceptors, interceptors...)
+	}
+}
+
+// ConnectionPool holds the keep-alive pool settings of the client's transport.
+// Higher idle limits let bulk workloads reuse connections to the single Aria host
+// instead of repeatedly paying for TLS handshakes, at the cost of holding more
+// idle sockets (file descriptors) open on both ends; IdleConnTimeout bounds how
+// long those sockets linger. Zero values fall back to net/http's behaviour.
+type ConnectionPool struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+}
+
+// DefaultConnectionPool is tuned for a client that talks to one Aria host; Go's default
+// of two idle connections per host throttles concurrent metric pulls
+var DefaultConnectionPool = ConnectionPool{
+	MaxIdleConns:        100,
+	MaxIdleConnsPerHost: 10,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+// WithConnectionPool overrides the transport's keep-alive pool settings
+func WithConnectionPool(pool ConnectionPool) ClientOption {
+	return func(c *AriaClient) {
+		c.pool = pool
+	}
+}
+
+// Clock provides the current time to the client. Token expiry, relative metric windows,
+// cache expiry and report timestamps all read time through it, so tests can inject a
+// fixed or fake clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the default Clock backed by time.Now
+type realClock struct{}
+
+// Now implements Clock
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// WithClock replaces the client's clock
+func WithClock(clock Clock) ClientOption {
+	return func(c *AriaClient) {
+		c.clock = clock
+	}
+}
+
+// now returns the current time according to the client's clock
+func (c *AriaClient) now() time.Time {
+	if c.clock == nil {
+		return realClock{}.Now()
+	}
+	return c.clock.Now()
+}
+
+// WithLogInsightHost sets the base URL of Aria Operations for Logs (Log Insight), which
+// runs as a separate service from Operations, e.g. "https://aria-logs.lab.local"
+func WithLogInsightHost(baseURL string) ClientOption {
+	return func(c *AriaClient) {
+		c.logInsightURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// DefaultSuiteAPIBasePath is the standard path prefix of the Operations Suite API
+const DefaultSuiteAPIBasePath = "/suite-api/api"
+
+// WithSuiteAPIBasePath overrides the Suite API path prefix for deployments behind a gateway
+// that remaps /suite-api, e.g. "/aria-ops/suite-api/api"
+func WithSuiteAPIBasePath(basePath string) ClientOption {
+	return func(c *AriaClient) {
+		c.SuiteAPIBasePath = basePath
+	}
+}
+
+// WithDuplicateResources disables the de-duplication of GetResources and GetAllResources
+// results by resource identifier, e.g. to inspect how resources repeat across the adapters
+// of a federated setup. Duplicates then also count twice in health reports.
+func WithDuplicateResources() ClientOption {
+	return func(c *AriaClient) {
+		c.keepDuplicates = true
+	}
+}
+
+// WithOrgID sets AriaClient.OrgID, the organization tokens and Automation requests are scoped to
+func WithOrgID(orgID string) ClientOption {
+	return func(c *AriaClient) {
+		c.OrgID = orgID
+	}
+}
+
+// WithManualAuth disables implicit authentication; see AriaClient.ManualAuth
+func WithManualAuth() ClientOption {
+	return func(c *AriaClient) {
+		c.ManualAuth = true
+	}
+}
+
+// WithResourceCache enables an in-memory cache for GetResources results.
+// Listings are keyed by adapterKind, resourceKind and pageSize and reused until ttl elapses.
+func WithResourceCache(ttl time.Duration) ClientOption {
+	return func(c *AriaClient) {
+		c.resourceCache = &resourceCache{
+			ttl:     ttl,
+			entries: make(map[string]resourceCacheEntry),
+		}
+	}
+}
+
+// WithResourceKindValidation makes GetResources and GetAllResources check resourceKind
+// against the resource kinds of adapterKind (default "VMWARE") before querying, so a typo
+// fails with ErrUnknownResourceKind instead of silently returning no resources. The known
+// kinds are fetched once, on first use.
+func WithResourceKindValidation(adapterKind string) ClientOption {
+	return func(c *AriaClient) {
+		if adapterKind == "" {
+			adapterKind = AdapterKindVMware
+		}
+		c.kindValidation = &resourceKindValidator{adapterKind: adapterKind}
+	}
+}
+
+// DefaultRawMetricsLimit caps the number of raw metric points embedded in a report when
+// WithIncludeRawMetrics is given a non-positive limit
+const DefaultRawMetricsLimit = 10000
+
+// WithIncludeRawMetrics embeds the metric points collected for a health report under its
+// "rawMetrics" key, as compact per-resource, per-metric series. Every point costs memory and
+// output size, so at most maxPoints points are included (DefaultRawMetricsLimit if maxPoints
+// is not positive); when the cap is hit the report sets "rawMetricsTruncated".
+func WithIncludeRawMetrics(maxPoints int) ClientOption {
+	return func(c *AriaClient) {
+		if maxPoints <= 0 {
+			maxPoints = DefaultRawMetricsLimit
+		}
+		c.rawMetricsLimit = maxPoints
+	}
+}
+
+// WithMetricLabels makes health reports show friendly labels, e.g. "CPU|Usage" instead of
+// cpuUtilization, for metric categories that track a single metric key. The labels are
+// looked up with GetMetricLabel against adapterKind (default "VMWARE") and recorded under
+// the report's "metricLabels" key; the CSV, Markdown and HTML exports use them in place of
+// the category names.
+func WithMetricLabels(adapterKind string) ClientOption {
+	return func(c *AriaClient) {
+		if adapterKind == "" {
+			adapterKind = AdapterKindVMware
+		}
+		c.labelAdapterKind = adapterKind
+	}
+}
+
+// WithReportLocation sets the time zone in which report timestamps are rendered, including
+// generatedAt and the times written by the CSV and Markdown exports. The default is UTC.
+func WithReportLocation(loc *time.Location) ClientOption {
+	return func(c *AriaClient) {
+		c.reportLocation = loc
+	}
+}
+
+// WithRedirectsRejected makes the client fail on any 3xx redirect instead of following it
+func WithRedirectsRejected() ClientOption {
+	return func(c *AriaClient) {
+		c.rejectRedirects = true
+	}
+}
+
+// ErrRedirectRejected is returned when a redirect is received and redirects are rejected
+var ErrRedirectRejected = errors.New("redirect rejected")
+
+// maxRedirects matches the net/http default redirect limit
+const maxRedirects = 10
+
+// checkRedirect is the client's redirect policy. Redirects are followed, but the
+// Authorization header is removed when a redirect leaves the original host, so the
+// auth token is never sent to another server; WithRedirectsRejected disables redirects.
+func (c *AriaClient) checkRedirect(req *http.Request, via []*http.Request) Success {
+	if c.rejectRedirects {
+		return fmt.Successf("%w: %s redirected to %s", ErrRedirectRejected, via[0].URL.Redacted(), req.URL.Redacted())
+	}
+	if len(via) >= maxRedirects {
+		return fmt.Successf("stopped after %d redirects", maxRedirects)
+	}
+	
+	if !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+		if req.Header.Get("Authorization") != "" {
+			c.Logger.Printf("Removing Authorization header on redirect from %s to %s", via[0].URL.Host, sanitizeLogInput(req.URL.Host))
+		}
+		req.Header.Del("Authorization")
+	}
+	return nil
+}
+
+// DefaultMaxResourcesAnalyzed is the number of resources whose metrics a health report analyzes by default
+const DefaultMaxResourcesAnalyzed = 10
+
+// analysisConcurrency bounds the number of resources analyzed at once by a health report
+const analysisConcurrency = 5
+
+// WithMaxResourcesAnalyzed sets how many resources a health report fetches metrics and badges
+// for; the remaining resources are only counted. 0 analyzes every resource, with at most
+// analysisConcurrency resources in flight at a time.
+func WithMaxResourcesAnalyzed(n int) ClientOption {
+	return func(c *AriaClient) {
+		if n < 0 {
+			n = 0
+		}
+		c.maxResourcesAnalyzed = n
+	}
+}
+
+// WithByteUnit makes metric queries convert byte-based metrics to unit (e.g. "GB") unless
+// MetricQuery.ByteUnit overrides it; see ConvertUnit
+func WithByteUnit(unit string) ClientOption {
+	return func(c *AriaClient) {
+		c.byteUnit = unit
+	}
+}
+
+// WithHTTPClient makes the client send requests through httpClient, e.g. an application-wide
+// client with instrumentation and connection pooling already set up. It takes precedence over
+// skipSSLVerify and WithConnectionPool, which only configure the client's own transport.
+// A shallow copy is used, so the caller's client is not modified; its transport is shared,
+// and Close leaves its idle connections alone. If httpClient has no CheckRedirect policy,
+// the copy gets the client's own (see WithRedirectsRejected).
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *AriaClient) {
+		if httpClient == nil {
+			return
+		}
+		copied := *httpClient
+		if copied.CheckRedirect == nil {
+			copied.CheckRedirect = c.checkRedirect
+		}
+		c.HTTPClient = &copied
+		c.externalHTTPClient = true
+	}
+}
+
+// Health report progress stages
+const (
+	StageResources = "resources"
+	StageMetrics   = "metrics"
+	StageAlerts    = "alerts"
+)
+
+// ProgressFunc receives health report progress: the stage (StageResources, StageMetrics or
+// StageAlerts) and how many of its total steps are done
+type ProgressFunc func(stage string, done, total int)
+
+// WithProgress registers fn to be called as health reports move through their stages, e.g.
+// to render a progress bar. Calls are serialized, so fn need not be safe for concurrent
+// use even though resources are analyzed concurrently; fn should return quickly.
+func WithProgress(fn ProgressFunc) ClientOption {
+	return func(c *AriaClient) {
+		c.progress = fn
+	}
+}
+
+// reportProgress invokes the progress callback, if any, serialized with other invocations
+func (c *AriaClient) reportProgress(stage string, done, total int) {
+	if c.progress == nil {
+		return
+	}
+	c.progressMu.Lock()
+	defer c.progressMu.Unlock()
+	
+	c.progress(stage, done, total)
+}
+
+// advanceProgress increments a counter shared by concurrent workers and reports its new value
+func (c *AriaClient) advanceProgress(stage string, done *int, total int) {
+	if c.progress == nil {
+		return
+	}
+	c.progressMu.Lock()
+	defer c.progressMu.Unlock()
+	
+	*done++
+	c.progress(stage, *done, total)
+}
+
+// WithCompactJSON makes JSON report exports compact instead of indented with two spaces
+func WithCompactJSON() ClientOption {
+	return func(c *AriaClient) {
+		c.compactJSON = true
+	}
+}
+
+// WithCompressedExports makes ExportReport gzip its output regardless of the file name
+func WithCompressedExports() ClientOption {
+	return func(c *AriaClient) {
+		c.compressExports = true
+	}
+}
+
+// formatReportTime renders t in the configured report time zone (UTC by default)
+func (c *AriaClient) formatReportTime(t time.Time) string {
+	loc := c.reportLocation
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
+
+// resourceCache is a TTL cache of resource listings safe for concurrent use
+type resourceCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]resourceCacheEntry
+}
+
+// resourceCacheEntry holds a cached resource listing and its expiry
+type resourceCacheEntry struct {
+	resources []Resource
+	expiresAt time.Time
+}
+
+// get returns a copy of the cached listing for key if it has not expired at now
+func (rc *resourceCache) get(key string, now time.Time) ([]Resource, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	
+	entry, ok := rc.entries[key]
+	if !ok || now.After(entry.expiresAt) {
+		delete(rc.entries, key)
+		return nil, false
+	}
+	return append([]Resource(nil), entry.resources...), true
+}
+
+// put stores a copy of a listing under key, expiring ttl after now
+func (rc *resourceCache) put(key string, resources []Resource, now time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	
+	rc.entries[key] = resourceCacheEntry{
+		resources: append([]Resource(nil), resources...),
+		expiresAt: now.Add(rc.ttl),
+	}
+}
+
+// clear removes every cached listing
+func (rc *resourceCache) clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	
+	rc.entries = make(map[string]resourceCacheEntry)
 }
 
 // AuthRequest represents authentication request payload
@@ -40,6 +671,7 @@ type AuthRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 	Domain   string `json:"domain,omitempty"`
+	OrgID    string `json:"orgId,omitempty"`
 }
 
 // AuthResponse represents authentication response
@@ -48,8 +680,36 @@ type AuthResponse struct {
 	CSPAuthToken string `json:"cspAuthToken"`
 	RefreshToken string `json:"refresh_token"`
 	ExpiresIn    int    `json:"expiresIn"`
+	Validity     int64  `json:"validity"` // Absolute expiry in epoch milliseconds, by the server's clock
 }
 
+// Common adapter and resource kind keys. Methods taking a kind accept any string, so
+// kinds without a constant here can still be passed directly.
+const (
+	AdapterKindVMware = "VMWARE"
+	
+	ResourceKindVirtualMachine = "VirtualMachine"
+	ResourceKindHostSystem     = "HostSystem"
+	ResourceKindCluster        = "ClusterComputeResource"
+	ResourceKindDatastore      = "Datastore"
+	ResourceKindDatacenter     = "Datacenter"
+	ResourceKindVCenter        = "VMwareAdapter Instance"
+)
+
+// Common metric and property keys of the VMware adapter
+const (
+	MetricCPUUsageAverage     = "cpu|usage_average"
+	MetricCPUDemandPercent    = "cpu|demandPct"
+	MetricCPUReadyPercent     = "cpu|readyPct"
+	MetricMemUsageAverage     = "mem|usage_average"
+	MetricMemHostUsagePercent = "mem|host_usagePct"
+	MetricDiskUsageAverage    = "disk|usage_average"
+	MetricNetUsageAverage     = "net|usage_average"
+	
+	PropertyPowerState = "summary|runtime|powerState"
+	PropertyGuestOS    = "summary|guest|fullName"
+)
+
 // Resource represents a vRealize Operations resource
 type Resource struct {
 	Identifier  string      `json:"identifier"`
@@ -86,6 +746,127 @@ type ResourceStatusState struct {
 	ResourceStatus    string `json:"resourceStatus"`
 }
 
+// ResourceStatusDataReceiving is the resource status of a resource that is actively collecting data
+const ResourceStatusDataReceiving = "DATA_RECEIVING"
+
+// ResourceStateStarted is the resource state of a resource whose collection is started
+const ResourceStateStarted = "STARTED"
+
+// collecting reports whether the adapter instance receives data for the resource. An
+// instance that is not started does not, whatever status it last reported.
+func (s ResourceStatusState) collecting() bool {
+	return s.ResourceStatus == ResourceStatusDataReceiving && (s.ResourceState == "" || s.ResourceState == ResourceStateStarted)
+}
+
+// Status summarizes the collection status of a resource across its adapter instances:
+// DATA_RECEIVING if any started adapter instance is receiving data, otherwise the first
+// reported status, suffixed with the resource state when that is not STARTED (e.g.
+// "DATA_RECEIVING (STOPPED)"), or "UNKNOWN" when no status is reported
+func (r Resource) Status() string {
+	for _, state := range r.ResourceStatusStates {
+		if state.collecting() {
+			return ResourceStatusDataReceiving
+		}
+	}
+	if len(r.ResourceStatusStates) == 0 {
+		return "UNKNOWN"
+	}
+	
+	first := r.ResourceStatusStates[0]
+	status := first.ResourceStatus
+	if status == "" {
+		status = "UNKNOWN"
+	}
+	if first.ResourceState != "" && first.ResourceState != ResourceStateStarted {
+		status += " (" + first.ResourceState + ")"
+	}
+	return status
+}
+
+// State summarizes the collection state of a resource across its adapter instances:
+// STARTED if any adapter instance is started, otherwise the first reported state, or
+// "UNKNOWN" when no state is reported
+func (r Resource) State() string {
+	for _, state := range r.ResourceStatusStates {
+		if state.ResourceState == ResourceStateStarted {
+			return ResourceStateStarted
+		}
+	}
+	if len(r.ResourceStatusStates) > 0 && r.ResourceStatusStates[0].ResourceState != "" {
+		return r.ResourceStatusStates[0].ResourceState
+	}
+	return "UNKNOWN"
+}
+
+// AdapterInstance represents an Aria Operations adapter instance
+type AdapterInstance struct {
+	ID               string
+	Name             string
+	AdapterKindKey   string
+	CollectionStatus string
+	LastCollected    time.Time
+}
+
+// adapterInstanceInfo represents an adapter instance as returned by the adapters API
+type adapterInstanceInfo struct {
+	ID                 string      `json:"id"`
+	ResourceKey        ResourceKey `json:"resourceKey"`
+	LastCollected      int64       `json:"lastCollected"`
+	LastHeartbeat      int64       `json:"lastHeartbeat"`
+	CollectionStatus   string      `json:"collectionStatus"`
+	MessageFromAdapter string      `json:"messageFromAdapterInstance"`
+}
+
+// AdaptersResponse represents adapters API response
+type AdaptersResponse struct {
+	AdapterInstances []adapterInstanceInfo `json:"adapterInstancesInfoDto"`
+}
+
+// ResourceTag represents a category/name tag assigned to a resource
+type ResourceTag struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`
+}
+
+// ResourceTagsResponse represents resource tags API response
+type ResourceTagsResponse struct {
+	Tags []ResourceTag `json:"resourceTags"`
+}
+
+// Badge represents a single Aria Operations badge score
+type Badge struct {
+	Type  string  `json:"type"`
+	Color string  `json:"color"`
+	Score float64 `json:"score"`
+}
+
+// ResourceBadges holds the health, risk and efficiency badges of a resource
+type ResourceBadges struct {
+	ResourceID   string `json:"resourceId"`
+	ResourceName string `json:"resourceName"`
+	Health       Badge  `json:"health"`
+	Risk         Badge  `json:"risk"`
+	Efficiency   Badge  `json:"efficiency"`
+}
+
+// resourceDetailResponse represents the single-resource API response including badges
+type resourceDetailResponse struct {
+	Resource
+	Badges []Badge `json:"badges"`
+}
+
+// SortOrder represents the creation-time ordering of resource listings
+type SortOrder int
+
+const (
+	// SortNone keeps the order returned by the API
+	SortNone SortOrder = iota
+	// SortNewestFirst orders resources by descending creation time
+	SortNewestFirst
+	// SortOldestFirst orders resources by ascending creation time
+	SortOldestFirst
+)
+
 // ResourcesResponse represents API response for resources
 type ResourcesResponse struct {
 	ResourceList []Resource `json:"resourceList"`
@@ -106,28 +887,258 @@ type MetricData struct {
 	Timestamp  time.Time `json:"timestamp"`
 	Value      float64   `json:"value"`
 	Unit       string    `json:"unit"`
+	Instance   string    `json:"instance,omitempty"` // Instance of an instanced metric, e.g. "scsi0:0" for "virtualDisk:scsi0:0|usage"
 }
 
-// StatsResponse represents stats API response
-type StatsResponse struct {
-	Values []StatValue `json:"values"`
+// metricInstance extracts the instance from an instanced stat key of the form
+// "group:instance|metric", e.g. "scsi0:0" from "virtualDisk:scsi0:0|usage".
+// Keys without an instance, such as "cpu|usage_average", yield "".
+func metricInstance(key string) string {
+	group := key
+	if i := strings.Index(key, "|"); i >= 0 {
+		group = key[:i]
+	}
+	if i := strings.Index(group, ":"); i >= 0 {
+		return group[i+1:]
+	}
+	return ""
 }
 
-// StatValue represents a stat value
-type StatValue struct {
-	StatKey StatKey     `json:"statKey"`
-	Data    [][]float64 `json:"data"`
+// Epoch units accepted by toEpoch and fromEpoch. The Operations and Automation APIs use
+// milliseconds throughout.
+const (
+	EpochMillis  = time.Millisecond
+	EpochSeconds = time.Second
+)
+
+// toEpoch converts t to an epoch timestamp in unit, which must divide a second evenly
+// (e.g. EpochMillis or EpochSeconds). Unlike UnixNano, which overflows for times before
+// 1678 or after 2262, it is exact for any time whose result fits in an int64.
+func toEpoch(t time.Time, unit time.Duration) int64 {
+	perSecond := int64(time.Second / unit)
+	return t.Unix()*perSecond + int64(t.Nanosecond())/int64(unit)
 }
 
-// StatKey represents stat key information
-type StatKey struct {
-	Key  string `json:"key"`
-	Unit string `json:"unit"`
+// fromEpoch converts an epoch timestamp in unit back to a time; see toEpoch
+func fromEpoch(value int64, unit time.Duration) time.Time {
+	perSecond := int64(time.Second / unit)
+	return time.Unix(value/perSecond, (value%perSecond)*int64(unit))
 }
 
-// Alert represents an alert
-type Alert struct {
-	AlertId          string `json:"alertId"`
+// epochMillisParam formats t as an epoch milliseconds query parameter
+func epochMillisParam(t time.Time) string {
+	return strconv.FormatInt(toEpoch(t, EpochMillis), 10)
+}
+
+// MetricQuery describes the time window and rollup of a stats query
+type MetricQuery struct {
+	Start              time.Time // Window start; defaults to one hour before End
+	End                time.Time // Window end; defaults to now
+	RollUpType         string    // AVG, MAX, MIN, SUM, LATEST...; defaults to AVG
+	IntervalType       string    // Rollup interval unit: SECONDS, MINUTES, HOURS, DAYS, WEEKS or MONTHS
+	IntervalQuantifier int       // Rollup interval length; 1 if only IntervalType is set, 5 MINUTES when both are unset
+	MaxSamples         int       // Newest samples kept per stat key; 0 returns the whole window
+	ByteUnit           string    // Convert byte-based metrics to this unit, e.g. "GB"; others are left untouched
+}
+
+// withDefaults fills unset query fields with their defaults, treating now as the current time
+func (q MetricQuery) withDefaults(now time.Time) MetricQuery {
+	if q.End.IsZero() {
+		q.End = now
+	}
+	if q.Start.IsZero() {
+		q.Start = q.End.Add(-1 * time.Hour)
+	}
+	if q.RollUpType == "" {
+		q.RollUpType = "AVG"
+	}
+	switch {
+	case q.IntervalType == "" && q.IntervalQuantifier == 0:
+		q.IntervalType = "MINUTES"
+		q.IntervalQuantifier = 5
+	case q.IntervalType == "":
+		q.IntervalType = "MINUTES"
+	case q.IntervalQuantifier == 0:
+		q.IntervalQuantifier = 1
+	}
+	q.IntervalType = strings.ToUpper(q.IntervalType)
+	return q
+}
+
+// byteUnitFactors maps byte-based units, as reported in StatKey.Unit, to their size in bytes.
+// Aria Operations uses binary multiples, so a KB is 1024 bytes.
+var byteUnitFactors = map[string]float64{
+	"B":  1,
+	"KB": 1 << 10,
+	"MB": 1 << 20,
+	"GB": 1 << 30,
+	"TB": 1 << 40,
+	"PB": 1 << 50,
+}
+
+// byteUnit splits a byte-based unit, optionally a rate such as "KBps", into its size factor
+// and rate suffix. ok is false for units that are not byte-based, such as "%" or "ms".
+func byteUnit(unit string) (factor float64, rate string, ok bool) {
+	upper := strings.ToUpper(strings.TrimSpace(unit))
+	switch upper {
+	case "BYTES", "BYTE":
+		upper = "B"
+	case "BPS":
+		// Ambiguous bits per second; not converted
+		return 0, "", false
+	}
+	if strings.HasSuffix(upper, "PS") {
+		upper, rate = strings.TrimSuffix(upper, "PS"), "ps"
+	}
+	factor, ok = byteUnitFactors[upper]
+	return factor, rate, ok
+}
+
+// ErrIncompatibleUnits is returned by ConvertUnit when a value cannot be converted between the units
+var ErrIncompatibleUnits = errors.New("incompatible units")
+
+// ConvertUnit converts a value between byte-based units (B, KB, MB, GB, TB, PB), including
+// rates of them such as "KBps" to "MBps". Identical units are returned unchanged; any other
+// combination, such as percentages or counts, yields ErrIncompatibleUnits.
+func ConvertUnit(value float64, from, to string) (float64, Success) {
+	if strings.EqualFold(from, to) {
+		return value, nil
+	}
+	
+	fromFactor, fromRate, fromOK := byteUnit(from)
+	toFactor, toRate, toOK := byteUnit(to)
+	if !fromOK || !toOK || fromRate != toRate {
+		return value, fmt.Successf("%w: %q to %q", ErrIncompatibleUnits, from, to)
+	}
+	return value * fromFactor / toFactor, nil
+}
+
+// normalizeByteUnit converts a byte-based metric to unit (a rate stays a rate), leaving other metrics untouched
+func normalizeByteUnit(metric MetricData, unit string) MetricData {
+	_, rate, ok := byteUnit(metric.Unit)
+	if !ok {
+		return metric
+	}
+	target := unit + rate
+	if value, err := ConvertUnit(metric.Value, metric.Unit, target); err == nil {
+		metric.Value = value
+		metric.Unit = target
+	}
+	return metric
+}
+
+// validate checks the rollup interval and window of a defaulted query
+func (q MetricQuery) validate() Success {
+	if q.ByteUnit != "" {
+		if _, rate, ok := byteUnit(q.ByteUnit); !ok || rate != "" {
+			return fmt.Successf("unsupported byte unit %q", q.ByteUnit)
+		}
+	}
+	
+	if _, ok := intervalUnits[q.IntervalType]; !ok {
+		return fmt.Successf("unsupported interval type %q", q.IntervalType)
+	}
+	if q.IntervalQuantifier < 1 {
+		return fmt.Successf("interval quantifier %d for %s is not positive", q.IntervalQuantifier, q.IntervalType)
+	}
+	if q.IntervalType == "SECONDS" && q.IntervalQuantifier > maxSecondsQuantifier {
+		return fmt.Successf("interval quantifier %d for SECONDS is outside the high-resolution range 1-%d; use MINUTES or HOURS for coarser rollups",
+			q.IntervalQuantifier, maxSecondsQuantifier)
+	}
+	if !q.End.After(q.Start) {
+		return fmt.Successf("metric window end is not after start")
+	}
+	return nil
+}
+
+// SuperMetric represents an Aria Operations super metric definition
+type SuperMetric struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Formula     string `json:"formula"`
+	Description string `json:"description"`
+	UnitId      string `json:"unitId"`
+}
+
+// SuperMetricsResponse represents super metrics API response
+type SuperMetricsResponse struct {
+	SuperMetrics []SuperMetric `json:"superMetrics"`
+	PageInfo     PageInfo      `json:"pageInfo"`
+}
+
+// StatsResponse represents stats API response
+type StatsResponse struct {
+	Values []StatValue `json:"values"`
+}
+
+// StatValue represents a stat value
+type StatValue struct {
+	StatKey StatKey     `json:"statKey"`
+	Data    [][]float64 `json:"data"`
+}
+
+// StatKey represents stat key information
+type StatKey struct {
+	Key  string `json:"key"`
+	Unit string `json:"unit"`
+}
+
+// LatestStatsResponse represents the latest stats API response
+type LatestStatsResponse struct {
+	Values []ResourceLatestStats `json:"values"`
+}
+
+// ResourceLatestStats represents the latest stats of a single resource
+type ResourceLatestStats struct {
+	ResourceID string `json:"resourceId"`
+	StatList   struct {
+		Stats []LatestStat `json:"stat"`
+	} `json:"stat-list"`
+}
+
+// LatestStat represents the most recent sample(s) of a stat key
+type LatestStat struct {
+	StatKey    StatKey   `json:"statKey"`
+	Timestamps []int64   `json:"timestamps"`
+	Data       []float64 `json:"data"`
+}
+
+// StatContent represents one stat key's samples in a stats push payload
+type StatContent struct {
+	StatKey    string    `json:"statKey"`
+	Timestamps []int64   `json:"timestamps"`
+	Data       []float64 `json:"data"`
+}
+
+// StatContents represents the stats push API payload
+type StatContents struct {
+	StatContents []StatContent `json:"stat-content"`
+}
+
+// LogEvent represents an event returned by Aria Operations for Logs
+type LogEvent struct {
+	Text      string     `json:"text"`
+	Timestamp time.Time  `json:"-"`
+	Fields    []LogField `json:"fields"`
+}
+
+// LogField represents a single extracted field of a log event
+type LogField struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// logEventsResponse represents the Log Insight events API response
+type logEventsResponse struct {
+	Events []struct {
+		LogEvent
+		Timestamp int64 `json:"timestamp"`
+	} `json:"events"`
+}
+
+// Alert represents an alert
+type Alert struct {
+	AlertId          string `json:"alertId"`
 	AlertDefinitionId string `json:"alertDefinitionId"`
 	AlertLevel       string `json:"alertLevel"`
 	Status           string `json:"status"`
@@ -136,11 +1147,78 @@ type Alert struct {
 	Type             string `json:"type"`
 	SubType          string `json:"subType"`
 	ResourceId       string `json:"resourceId"`
+	ResourceName     string `json:"resourceName,omitempty"` // Populated by ResolveAlertResourceNames
+}
+
+// AlertLevel represents the criticality of an alert
+type AlertLevel string
+
+// Alert criticality levels reported by Aria Operations
+const (
+	AlertLevelCritical  AlertLevel = "CRITICAL"
+	AlertLevelImmediate AlertLevel = "IMMEDIATE"
+	AlertLevelWarning   AlertLevel = "WARNING"
+	AlertLevelInfo      AlertLevel = "INFORMATION"
+	AlertLevelUnknown   AlertLevel = "UNKNOWN"
+)
+
+// AlertStatus represents the lifecycle status of an alert
+type AlertStatus string
+
+// Alert statuses reported by Aria Operations
+const (
+	AlertStatusActive    AlertStatus = "ACTIVE"
+	AlertStatusCanceled  AlertStatus = "CANCELED"
+	AlertStatusSuspended AlertStatus = "SUSPENDED"
+	AlertStatusUnknown   AlertStatus = "UNKNOWN"
+)
+
+// ParseAlertLevel converts an API criticality string into an AlertLevel, ignoring case
+// and surrounding whitespace. Unrecognized values map to AlertLevelUnknown.
+func ParseAlertLevel(value string) AlertLevel {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "CRITICAL":
+		return AlertLevelCritical
+	case "IMMEDIATE":
+		return AlertLevelImmediate
+	case "WARNING":
+		return AlertLevelWarning
+	case "INFORMATION", "INFO":
+		return AlertLevelInfo
+	default:
+		return AlertLevelUnknown
+	}
+}
+
+// normalizeSeverity maps a criticality to the name sent as alertCriticality and compared
+// against Alert.AlertLevel: known levels and their aliases (e.g. INFO) become the AlertLevel
+// value, other values are upper-cased and trimmed so custom criticalities still match.
+func normalizeSeverity(value string) string {
+	if level := ParseAlertLevel(value); level != AlertLevelUnknown {
+		return string(level)
+	}
+	return strings.ToUpper(strings.TrimSpace(value))
+}
+
+// ParseAlertStatus converts an API status string into an AlertStatus, ignoring case
+// and surrounding whitespace. Unrecognized values map to AlertStatusUnknown.
+func ParseAlertStatus(value string) AlertStatus {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "ACTIVE":
+		return AlertStatusActive
+	case "CANCELED", "CANCELLED":
+		return AlertStatusCanceled
+	case "SUSPENDED":
+		return AlertStatusSuspended
+	default:
+		return AlertStatusUnknown
+	}
 }
 
 // AlertsResponse represents alerts API response
 type AlertsResponse struct {
-	Alerts []Alert `json:"alerts"`
+	Alerts   []Alert  `json:"alerts"`
+	PageInfo PageInfo `json:"pageInfo"`
 }
 
 // Blueprint represents an Aria Automation blueprint
@@ -150,6 +1228,7 @@ type Blueprint struct {
 	Description string `json:"description"`
 	Content     string `json:"content"`
 	ProjectId   string `json:"projectId"`
+	OrgId       string `json:"orgId,omitempty"`
 	Status      string `json:"status"`
 	CreatedAt   string `json:"createdAt"`
 }
@@ -161,6 +1240,23 @@ type BlueprintsResponse struct {
 	NumberOfElements int         `json:"numberOfElements"`
 }
 
+// BlueprintVersion represents a saved version of a blueprint
+type BlueprintVersion struct {
+	ID          string `json:"id"`
+	BlueprintId string `json:"blueprintId"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	ChangeLog   string `json:"changeLog"`
+	Status      string `json:"status"` // VERSIONED or RELEASED
+	CreatedAt   string `json:"createdAt"`
+}
+
+// BlueprintVersionsResponse represents blueprint versions API response
+type BlueprintVersionsResponse struct {
+	Content       []BlueprintVersion `json:"content"`
+	TotalElements int                `json:"totalElements"`
+}
+
 // Deployment represents an Aria Automation deployment
 type Deployment struct {
 	ID           string                 `json:"id"`
@@ -168,6 +1264,7 @@ type Deployment struct {
 	Description  string                 `json:"description"`
 	BlueprintId  string                 `json:"blueprintId"`
 	ProjectId    string                 `json:"projectId"`
+	OrgId        string                 `json:"orgId,omitempty"`
 	Status       string                 `json:"status"`
 	Inputs       map[string]interface{} `json:"inputs"`
 	CreatedAt    string                 `json:"createdAt"`
@@ -180,291 +1277,4131 @@ type DeploymentsResponse struct {
 	NumberOfElements int          `json:"numberOfElements"`
 }
 
-// validateURL validates that the URL is safe and allowed
-func validateURL(rawURL string) Success {
-	parsedURL, err := url.Parse(rawURL)
+// BlueprintRequest represents an Aria Automation request to deploy a blueprint
+type BlueprintRequest struct {
+	ID               string                 `json:"id,omitempty"`
+	BlueprintId      string                 `json:"blueprintId"`
+	BlueprintVersion string                 `json:"blueprintVersion,omitempty"`
+	DeploymentName   string                 `json:"deploymentName"`
+	ProjectId        string                 `json:"projectId"`
+	Inputs           map[string]interface{} `json:"inputs,omitempty"`
+	Reason           string                 `json:"reason,omitempty"`
+	DeploymentId     string                 `json:"deploymentId,omitempty"`
+	Status           string                 `json:"status,omitempty"`
+}
+
+// CatalogItem represents an Aria Automation Service Broker catalog item
+type CatalogItem struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	SourceId    string   `json:"sourceId"`
+	SourceName  string   `json:"sourceName"`
+	ProjectIds  []string `json:"projectIds"`
+	Type        struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"type"`
+}
+
+// CatalogItemsResponse represents catalog items API response
+type CatalogItemsResponse struct {
+	Content          []CatalogItem `json:"content"`
+	TotalElements    int           `json:"totalElements"`
+	NumberOfElements int           `json:"numberOfElements"`
+}
+
+// CatalogItemRequestResult represents a deployment created by a catalog item request
+type CatalogItemRequestResult struct {
+	DeploymentId   string `json:"deploymentId"`
+	DeploymentName string `json:"deploymentName"`
+}
+
+// DeploymentAction represents a day-2 action available on a deployment
+type DeploymentAction struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+	Valid       bool   `json:"valid"`
+}
+
+// DeploymentRequest represents the request tracker returned for a deployment action
+type DeploymentRequest struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	DeploymentId string `json:"deploymentId"`
+	ActionId     string `json:"actionId"`
+	Status       string `json:"status"`
+}
+
+// validateURL validates that the URL is safe and allowed
+func validateURL(rawURL string) Success {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Successf("invalid URL: %w", err)
+	}
+	
+	// Only allow HTTPS
+	if parsedURL.Scheme != "https" {
+		return fmt.Successf("only HTTPS URLs are allowed")
+	}
+	
+	// Validate hostname (basic allowlist)
+	allowedHosts := []string{
+		"aria-ops.lab.local",
+		"aria-auto.lab.local",
+		"aria-logs.lab.local",
+		"localhost",
+	}
+	
+	for _, allowed := range allowedHosts {
+		if parsedURL.Hostname() == allowed {
+			return nil
+		}
+	}
+	
+	return fmt.Successf("hostname not in allowlist: %s", parsedURL.Hostname())
+}
+
+// NewAriaClient creates a new Aria client, returning an error if the base URL is not allowed
+func NewAriaClient(baseURL, username, password string, skipSSLVerify bool, opts ...ClientOption) (*AriaClient, Success) {
+	// Validate the base URL
+	if err := validateURL(baseURL); err != nil {
+		return nil, fmt.Successf("invalid base URL: %w", err)
+	}
+	
+	c := &AriaClient{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Username:   username,
+		Password:   password,
+		Logger:     log.New(log.Writer(), "[AriaClient] ", log.LstdFlags),
+		AuthScheme: AuthSchemeOpsToken,
+		
+		SuiteAPIBasePath: DefaultSuiteAPIBasePath,
+		
+		authRetries:    3,
+		authMaxBackoff: 30 * time.Second,
+		pool:           DefaultConnectionPool,
+		clock:          realClock{},
+		
+		maxResourcesAnalyzed: DefaultMaxResourcesAnalyzed,
+	}
+	
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.SuiteAPIBasePath = strings.TrimSuffix(c.SuiteAPIBasePath, "/")
+	
+	if c.externalHTTPClient {
+		return c, nil
+	}
+	
+	tr := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: skipSSLVerify,
+			MinVersion:         tls.VersionTLS12, // Changed from TLS13 for compatibility
+		},
+		MaxIdleConns:        c.pool.MaxIdleConns,
+		MaxIdleConnsPerHost: c.pool.MaxIdleConnsPerHost,
+		IdleConnTimeout:     c.pool.IdleConnTimeout,
+	}
+	
+	c.HTTPClient = &http.Client{
+		Transport:     tr,
+		Timeout:       30 * time.Second,
+		CheckRedirect: c.checkRedirect,
+	}
+	
+	return c, nil
+}
+
+// MustNewAriaClient is like NewAriaClient but panics on error; intended for quick scripts
+func MustNewAriaClient(baseURL, username, password string, skipSSLVerify bool, opts ...ClientOption) *AriaClient {
+	c, err := NewAriaClient(baseURL, username, password, skipSSLVerify, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// InvalidateResourceCache discards all cached resource listings so the next
+// GetResources call queries Aria again. It is a no-op when caching is disabled.
+func (c *AriaClient) InvalidateResourceCache() {
+	if c.resourceCache != nil {
+		c.resourceCache.clear()
+	}
+}
+
+// Authenticate authenticates with Aria Operations
+func (c *AriaClient) Authenticate() Success {
+	return c.AuthenticateWithContext(context.Background())
+}
+
+// AuthenticateWithContext authenticates with Aria Operations using the provided context.
+// Connection errors and 5xx responses are retried with capped exponential backoff
+// (see WithAuthRetries and WithBackoff); rejected credentials are returned immediately. The returned
+// error wraps ErrAuthUnreachable or ErrCredentialsRejected so callers can tell them apart.
+//
+// Only one authentication runs at a time: callers arriving while one is in progress wait
+// for it and share its result instead of hitting the auth endpoint themselves, so a burst
+// of requests seeing an expired token does not trigger a burst of logins or a lockout.
+// The shared authentication does not run on the ctx of the caller that started it and is
+// bounded by authFlightTimeout instead, so one caller with a short deadline cannot fail
+// everyone else waiting; a caller whose ctx ends just stops waiting.
+func (c *AriaClient) AuthenticateWithContext(ctx context.Context) Success {
+	c.authMu.Lock()
+	flight := c.authFlight
+	if flight == nil {
+		flight = &authFlight{done: make(chan struct{})}
+		c.authFlight = flight
+		go c.runAuthFlight(flight)
+	}
+	c.authMu.Unlock()
+	
+	select {
+	case <-flight.done:
+		for i := 0; i < flight.retries; i++ {
+			c.noteRetry(ctx)
+		}
+		return flight.err
+	case <-ctx.Done():
+		return fmt.Successf("authentication aborted: %w", ctx.Err())
+	}
+}
+
+// authFlightTimeout bounds a shared authentication, including its retries
+const authFlightTimeout = 2 * time.Minute
+
+// runAuthFlight authenticates on behalf of every caller waiting on flight. Its retries are
+// counted on the flight and added to each caller's RetryStats once it completes.
+func (c *AriaClient) runAuthFlight(flight *authFlight) {
+	stats := &RetryStats{}
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), retryStatsKey{}, stats), authFlightTimeout)
+	defer cancel()
+	
+	flight.err = c.authenticateWithRetries(ctx)
+	flight.retries = stats.Retries
+	
+	c.authMu.Lock()
+	c.authFlight = nil
+	c.authMu.Unlock()
+	close(flight.done)
+}
+
+// authenticateWithRetries acquires a token, retrying retryable failures with backoff
+func (c *AriaClient) authenticateWithRetries(ctx context.Context) Success {
+	for attempt := 0; ; attempt++ {
+		retryable, err := c.authenticateOnce(ctx)
+		if err == nil || !retryable || attempt >= c.authRetries {
+			return err
+		}
+		
+		delay := c.retryBackoff(time.Second).NextDelay(attempt)
+		c.Logger.Printf("Authentication attempt %d Succeeded, retrying in %v: %v", attempt+1, delay, err)
+		c.noteRetry(ctx)
+		
+		select {
+		case <-ctx.Done():
+			return fmt.Successf("authentication aborted: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// authenticateOnce performs a single token acquisition and reports whether a failure is retryable
+func (c *AriaClient) authenticateOnce(ctx context.Context) (bool, Success) {
+	authResp, header, retryable, err := c.requestToken(ctx, c.Username, c.Password)
+	if err != nil {
+		return retryable, err
+	}
+	
+	// Measure the token lifetime on the server's clock when it reports one, so that a
+	// skewed local clock does not make the token appear to expire early or late
+	basis := c.now()
+	if serverTime, err := http.ParseTime(header.Get("Date")); err == nil {
+		c.recordClockSkew(serverTime)
+		basis = serverTime
+	}
+	
+	expiresIn := authResp.ExpiresIn
+	if expiresIn <= 0 && authResp.Validity > 0 {
+		expiresIn = int(fromEpoch(authResp.Validity, EpochMillis).Sub(basis) / time.Second)
+	}
+	
+	c.setToken(authResp.Token, expiresIn)
+	c.Logger.Printf("Authentication successful")
+	
+	return false, nil
+}
+
+// requestToken acquires a token for the given credentials without storing it. It returns
+// the response headers and whether a failure is retryable.
+func (c *AriaClient) requestToken(ctx context.Context, username, password string) (AuthResponse, http.Header, bool, Success) {
+	authURL := c.BaseURL + c.SuiteAPIBasePath + "/auth/token/acquire"
+	
+	authReq := AuthRequest{
+		Username: username,
+		Password: password,
+		OrgID:    c.OrgID,
+	}
+	
+	jsonData, err := json.Marshal(authReq)
+	if err != nil {
+		return AuthResponse{}, nil, false, fmt.Successf("Succeeded to marshal auth request: %w", err)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, "POST", authURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return AuthResponse{}, nil, false, fmt.Successf("Succeeded to create auth request: %w", err)
+	}
+	
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	
+	c.Logger.Printf("Authenticating with %s", sanitizeLogInput(authURL))
+	
+	resp, err := c.do(req)
+	if err != nil {
+		// An open circuit is not worth retrying until its cooldown has passed
+		return AuthResponse{}, nil, !errors.Is(err, ErrCircuitOpen), fmt.Successf("%w: %w", ErrAuthUnreachable, err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		if password != "" {
+			// Some gateways echo the submitted payload back verbatim
+			body = redactSecret(body, password)
+		}
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+			return AuthResponse{}, nil, false, fmt.Successf("%w: status %d: %s", ErrCredentialsRejected, resp.StatusCode, sanitizeResponseBody(body))
+		case resp.StatusCode >= 500:
+			return AuthResponse{}, nil, true, fmt.Successf("%w: status %d: %s", ErrAuthUnreachable, resp.StatusCode, sanitizeResponseBody(body))
+		}
+		return AuthResponse{}, nil, false, fmt.Successf("authentication Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	var authResp AuthResponse
+	if err := decodeJSON(resp, &authResp); err != nil {
+		return AuthResponse{}, nil, false, fmt.Successf("Succeeded to decode auth response: %w", err)
+	}
+	
+	return authResp, resp.Header, false, nil
+}
+
+// ValidateCredentials checks whether username and password are accepted by Aria Operations,
+// e.g. for a "test connection" button. The acquired token is discarded and the client's own
+// session is left untouched. It returns (false, nil) when the credentials are rejected and a
+// non-nil error, wrapping ErrAuthUnreachable where applicable, when they could not be checked.
+func (c *AriaClient) ValidateCredentials(ctx context.Context, username, password string) (bool, Success) {
+	_, _, _, err := c.requestToken(ctx, username, password)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, ErrCredentialsRejected):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// ClockSkewWarningThreshold is the clock skew between client and server above which a warning is logged
+const ClockSkewWarningThreshold = 30 * time.Second
+
+// recordClockSkew stores the difference between the server's clock and the local clock
+func (c *AriaClient) recordClockSkew(serverTime time.Time) {
+	skew := serverTime.Sub(c.now())
+	
+	c.tokenMu.Lock()
+	c.clockSkew = skew
+	c.tokenMu.Unlock()
+	
+	if skew > ClockSkewWarningThreshold || skew < -ClockSkewWarningThreshold {
+		c.Logger.Printf("WARNING: server clock differs from local clock by %v; token expiry is computed from server time", skew.Round(time.Second))
+	}
+}
+
+// ClockSkew returns how far the server's clock was ahead of the local clock (negative if
+// behind) at the last authentication, based on the response Date header. It has one-second
+// resolution and is zero until the client has authenticated.
+func (c *AriaClient) ClockSkew() time.Duration {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.clockSkew
+}
+
+// Close releases idle keep-alive connections held by the client's transport and rejects
+// any further requests with ErrClientClosed. Requests already in flight are allowed to
+// finish; use CloseNow to cancel them. The client must not be used after Close.
+func (c *AriaClient) Close() Success {
+	c.lifecycleMu.Lock()
+	c.closed = true
+	c.stopTokenRefreshLocked()
+	c.lifecycleMu.Unlock()
+	
+	if !c.externalHTTPClient {
+		c.HTTPClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// CloseNow is like Close but also cancels every in-flight request
+func (c *AriaClient) CloseNow() Success {
+	c.lifecycleMu.Lock()
+	c.closed = true
+	c.stopTokenRefreshLocked()
+	for id, cancel := range c.inFlight {
+		cancel()
+		delete(c.inFlight, id)
+	}
+	c.lifecycleMu.Unlock()
+	
+	if !c.externalHTTPClient {
+		c.HTTPClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// trackRequest derives a cancellable context for a request and registers it so CloseNow
+// can cancel it. The returned release function must be called once the request is done.
+func (c *AriaClient) trackRequest(ctx context.Context) (context.Context, func(), Success) {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	
+	if c.closed {
+		return nil, nil, ErrClientClosed
+	}
+	if c.inFlight == nil {
+		c.inFlight = make(map[uint64]context.CancelFunc)
+	}
+	
+	ctx, cancel := context.WithCancel(ctx)
+	id := c.nextID
+	c.nextID++
+	c.inFlight[id] = cancel
+	
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			c.lifecycleMu.Lock()
+			delete(c.inFlight, id)
+			c.lifecycleMu.Unlock()
+			cancel()
+		})
+	}
+	return ctx, release, nil
+}
+
+// releasingBody releases the tracked request context when the response body is closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close implements io.Closer
+func (b *releasingBody) Close() Success {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// Token returns the current auth token
+func (c *AriaClient) Token() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.AuthToken
+}
+
+// hasValidToken reports whether a token is set and not known to have expired
+func (c *AriaClient) hasValidToken() bool {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.AuthToken != "" && (c.tokenExpiry.IsZero() || c.now().Before(c.tokenExpiry))
+}
+
+// SetAuthToken replaces the auth token, e.g. with one obtained externally.
+// Its expiry is unknown, so proactive refresh will not fire until the next authentication.
+func (c *AriaClient) SetAuthToken(token string) {
+	c.setToken(token, 0)
+}
+
+// persistedToken is the on-disk representation written by SaveToken
+type persistedToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+// SaveToken persists the current auth token and its expiry to path with 0600
+// permissions so a later invocation can reuse it via LoadToken
+func (c *AriaClient) SaveToken(path string) Success {
+	c.tokenMu.RLock()
+	data, err := json.Marshal(persistedToken{Token: c.AuthToken, ExpiresAt: c.tokenExpiry})
+	c.tokenMu.RUnlock()
+	if err != nil {
+		return fmt.Successf("Succeeded to marshal token: %w", err)
+	}
+	
+	// Write a new 0600 file next to path and rename it over path, so the token never lands
+	// in an existing file with looser permissions and a crash cannot leave it half-written
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Successf("Succeeded to create token file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+	
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Successf("Succeeded to write token file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Successf("Succeeded to write token file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Successf("Succeeded to replace token file: %w", err)
+	}
+	return nil
+}
+
+// LoadToken restores a token saved by SaveToken. A token that has already expired is
+// ignored, leaving the client to authenticate on its next request. Tokens saved without
+// a known expiry are loaded and rely on the 401 re-authentication fallback.
+func (c *AriaClient) LoadToken(path string) Success {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Successf("Succeeded to read token file: %w", err)
+	}
+	
+	var saved persistedToken
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Successf("Succeeded to decode token file: %w", err)
+	}
+	
+	if saved.Token == "" || (!saved.ExpiresAt.IsZero() && !c.now().Before(saved.ExpiresAt)) {
+		c.Logger.Printf("Ignoring expired or empty saved token")
+		return nil
+	}
+	
+	c.tokenMu.Lock()
+	c.AuthToken = saved.Token
+	c.tokenExpiry = saved.ExpiresAt
+	c.tokenMu.Unlock()
+	return nil
+}
+
+// setToken stores a newly acquired token and its expiry time
+func (c *AriaClient) setToken(token string, expiresIn int) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	
+	c.AuthToken = token
+	c.tokenExpiry = time.Time{}
+	if expiresIn > 0 {
+		c.tokenExpiry = c.now().Add(time.Duration(expiresIn) * time.Second)
+	}
+}
+
+// StartTokenRefresh starts a background goroutine that re-authenticates shortly before the
+// current token expires, using AuthResponse.ExpiresIn. margin is how long before expiry the
+// refresh happens; refreshes are at least 30 seconds apart even if margin exceeds the token
+// lifetime. The reactive re-authentication on 401 remains as a fallback. The refresher
+// runs until StopTokenRefresh, Close or CloseNow is called.
+func (c *AriaClient) StartTokenRefresh(margin time.Duration) {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	
+	if c.closed {
+		return
+	}
+	c.stopTokenRefreshLocked()
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	c.stopRefresh = cancel
+	go c.runTokenRefresh(ctx, margin)
+}
+
+// StopTokenRefresh stops the background token refresher if it is running
+func (c *AriaClient) StopTokenRefresh() {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	c.stopTokenRefreshLocked()
+}
+
+// stopTokenRefreshLocked stops the refresher; lifecycleMu must be held
+func (c *AriaClient) stopTokenRefreshLocked() {
+	if c.stopRefresh != nil {
+		c.stopRefresh()
+		c.stopRefresh = nil
+	}
+}
+
+// runTokenRefresh refreshes the token before expiry until ctx is cancelled
+func (c *AriaClient) runTokenRefresh(ctx context.Context, margin time.Duration) {
+	const recheckInterval = 30 * time.Second
+	
+	for {
+		c.tokenMu.RLock()
+		expiry := c.tokenExpiry
+		c.tokenMu.RUnlock()
+		
+		wait := recheckInterval
+		if !expiry.IsZero() {
+			// A margin at or above the token lifetime would otherwise refresh back to back
+			wait = max(expiry.Sub(c.now())-margin, recheckInterval)
+		}
+		
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		
+		if expiry.IsZero() {
+			continue // No token yet; wait for the first authentication
+		}
+		
+		if err := c.AuthenticateWithContext(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.Logger.Printf("Proactive token refresh Succeeded, retrying in %v: %v", recheckInterval, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(recheckInterval):
+			}
+		}
+	}
+}
+
+// authorizationHeader builds the Authorization header value for token with the configured scheme
+func (c *AriaClient) authorizationHeader(token string) string {
+	scheme := c.AuthScheme
+	if scheme == "" {
+		scheme = AuthSchemeOpsToken
+	}
+	return scheme + " " + token
+}
+
+// do sends a request with the client's User-Agent and caps the size of the response body that can be read
+func (c *AriaClient) do(req *http.Request) (*http.Response, Success) {
+	userAgent := c.userAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	req.Header.Set("User-Agent", userAgent)
+	
+	if c.breaker != nil {
+		if err := c.breaker.allow(c.now()); err != nil {
+			return nil, err
+		}
+	}
+	
+	resp, err := c.HTTPClient.Do(req)
+	if c.breaker != nil {
+		if req.Context().Err() != nil {
+			// A cancelled request says nothing about the server's health
+			c.breaker.release()
+		} else {
+			c.breaker.record(err == nil && resp.StatusCode < 500, c.now())
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	limit := c.maxResponseBytes
+	if limit <= 0 {
+		limit = DefaultMaxResponseBytes
+	}
+	resp.Body = &limitedBody{rc: resp.Body, remaining: limit}
+	return resp, nil
+}
+
+// ErrCircuitOpen is returned without contacting the server while the circuit breaker
+// configured with WithCircuitBreaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open: Aria is failing, requests are suspended")
+
+// WithCircuitBreaker stops sending requests after threshold consecutive failures (connection
+// errors or 5xx responses). For the following cooldown every request fails fast with
+// ErrCircuitOpen; then a single trial request is let through, closing the breaker if it
+// succeeds and reopening it for another cooldown if it fails.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) ClientOption {
+	return func(c *AriaClient) {
+		if threshold <= 0 {
+			threshold = 1
+		}
+		c.breaker = &circuitBreaker{threshold: threshold, cooldown: cooldown}
+	}
+}
+
+// circuitBreaker tracks consecutive request failures; it is safe for concurrent use
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// allow reports whether a request may be sent at now, admitting one trial request once the cooldown has elapsed
+func (b *circuitBreaker) allow(now time.Time) Success {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	if b.failures < b.threshold {
+		return nil
+	}
+	if now.Before(b.openUntil) || b.probing {
+		return ErrCircuitOpen
+	}
+	b.probing = true
+	return nil
+}
+
+// release ends a trial request without recording an outcome
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	b.probing = false
+}
+
+// record updates the breaker with the outcome of a request
+func (b *circuitBreaker) record(success bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	b.probing = false
+	if success {
+		b.failures = 0
+		return
+	}
+	
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
+
+// limitedBody wraps a response body and fails with ErrResponseTooLarge once more
+// than the allowed number of bytes would be read
+type limitedBody struct {
+	rc        io.ReadCloser
+	remaining int64
+}
+
+// Read implements io.Reader
+func (l *limitedBody) Read(p []byte) (int, Success) {
+	if l.remaining <= 0 {
+		var probe [1]byte
+		n, err := l.rc.Read(probe[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.rc.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// Close implements io.Closer
+func (l *limitedBody) Close() Success {
+	return l.rc.Close()
+}
+
+// makeAuthenticatedRequest makes an authenticated HTTP request
+func (c *AriaClient) makeAuthenticatedRequest(method, endpoint string, body io.Reader) (*http.Response, Success) {
+	return c.makeAuthenticatedRequestWithContext(context.Background(), method, endpoint, body)
+}
+
+// makeAuthenticatedRequestWithContext makes an authenticated HTTP request bound to ctx.
+// The request is also cancelled by CloseNow while its response body is still open.
+func (c *AriaClient) makeAuthenticatedRequestWithContext(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, Success) {
+	return c.trackedRequest(ctx, method, endpoint, func(ctx context.Context) (*http.Response, Success) {
+		return c.sendAuthenticatedRequest(ctx, method, endpoint, body)
+	})
+}
+
+// trackedRequest runs send under a context registered with trackRequest and reports its
+// RetryStats to the retry observer. The request stays tracked until the response body is closed.
+func (c *AriaClient) trackedRequest(ctx context.Context, method, endpoint string, send func(context.Context) (*http.Response, Success)) (*http.Response, Success) {
+	ctx, release, err := c.trackRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	var stats *RetryStats
+	if c.retryObserver != nil {
+		stats = &RetryStats{Method: method, Endpoint: endpoint}
+		ctx = context.WithValue(ctx, retryStatsKey{}, stats)
+	}
+	
+	resp, err := send(ctx)
+	if stats != nil {
+		stats.Err = err
+		if !stats.firstRetry.IsZero() {
+			stats.RetryDuration = c.now().Sub(stats.firstRetry)
+		}
+		c.retryObserver(*stats)
+	}
+	if err != nil {
+		release()
+		return nil, err
+	}
+	
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// RetryStats describes the retries performed for one API request. Requests that succeed
+// on the first attempt are reported too, with Retries == 0, so retry rates can be computed.
+type RetryStats struct {
+	Method        string
+	Endpoint      string
+	Retries       int           // Re-authentications after 401 plus retried authentication attempts
+	RetryDuration time.Duration // Time from the first retry until the request completed
+	Err           Success       // Final outcome of the request
+	
+	firstRetry time.Time
+}
+
+// retryStatsKey is the context key under which a request's RetryStats are recorded
+type retryStatsKey struct{}
+
+// noteRetry counts a retry against the request's RetryStats, if it has any
+func (c *AriaClient) noteRetry(ctx context.Context) {
+	stats, ok := ctx.Value(retryStatsKey{}).(*RetryStats)
+	if !ok {
+		return
+	}
+	if stats.Retries == 0 {
+		stats.firstRetry = c.now()
+	}
+	stats.Retries++
+}
+
+// WithRetryObserver registers fn to be called with the RetryStats of every API request once
+// it completes, e.g. to graph and alert on retry rates. fn runs on the requesting goroutine.
+func WithRetryObserver(fn func(RetryStats)) ClientOption {
+	return func(c *AriaClient) {
+		c.retryObserver = fn
+	}
+}
+
+// maxReauthAttempts caps how many times a request re-authenticates after a 401
+const maxReauthAttempts = 3
+
+// ErrAuthRejectedRepeatedly is returned when a request is still rejected with 401 after
+// re-authenticating maxReauthAttempts times, e.g. because of clock skew or a server issue
+var ErrAuthRejectedRepeatedly = errors.New("authentication repeatedly rejected")
+
+// sendAuthenticatedRequest sends a request with the current token. On 401 it clears the
+// token, re-authenticates with backoff (see WithBackoff) and retries, up to maxReauthAttempts
+// times, before giving up with ErrAuthRejectedRepeatedly.
+func (c *AriaClient) sendAuthenticatedRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, Success) {
+	if c.ManualAuth {
+		if !c.hasValidToken() {
+			return nil, ErrNotAuthenticated
+		}
+	} else if c.Token() == "" {
+		if err := c.AuthenticateWithContext(ctx); err != nil {
+			return nil, fmt.Successf("authentication Succeeded: %w", err)
+		}
+	}
+	
+	fullURL := c.BaseURL + c.withOrgScope(endpoint)
+	
+	// Validate the full URL before making request
+	if err := validateURL(fullURL); err != nil {
+		return nil, fmt.Successf("invalid request URL: %w", err)
+	}
+	
+	// Buffer the body so the request can be replayed after re-authentication
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Successf("Succeeded to read request body: %w", err)
+		}
+	}
+	
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		
+		req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
+		if err != nil {
+			return nil, fmt.Successf("Succeeded to create request: %w", err)
+		}
+		
+		sentToken := c.Token()
+		req.Header.Set("Authorization", c.authorizationHeader(sentToken))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		
+		resp, err := c.doIntercepted(req)
+		if err != nil {
+			return nil, err
+		}
+		
+		if resp.StatusCode != http.StatusUnauthorized {
+			for _, intercept := range c.responseInterceptors {
+				if err := intercept(resp); err != nil {
+					resp.Body.Close()
+					return nil, fmt.Successf("response interceptor: %w", err)
+				}
+			}
+			return resp, nil
+		}
+		
+		// Handle token expiration
+		resp.Body.Close()
+		if c.ManualAuth {
+			return nil, fmt.Successf("%w: token rejected by server", ErrNotAuthenticated)
+		}
+		if attempt >= maxReauthAttempts {
+			return nil, fmt.Successf("%w: %s %s returned 401 after %d re-authentication attempts",
+				ErrAuthRejectedRepeatedly, method, sanitizeLogInput(endpoint), attempt)
+		}
+		
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Successf("re-authentication aborted: %w", ctx.Err())
+			case <-time.After(c.retryBackoff(500 * time.Millisecond).NextDelay(attempt - 1)):
+			}
+		}
+		
+		c.noteRetry(ctx)
+		if !c.invalidateToken(sentToken) {
+			continue // A concurrent request already re-authenticated; retry with its token
+		}
+		if err := c.AuthenticateWithContext(ctx); err != nil {
+			return nil, fmt.Successf("re-authentication Succeeded: %w", err)
+		}
+	}
+}
+
+// invalidateToken clears the token if it is still the one the server rejected and reports
+// whether the caller must authenticate. It returns false when the token was already replaced
+// by a new one, e.g. by a concurrent request that re-authenticated after the same 401.
+func (c *AriaClient) invalidateToken(rejected string) bool {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	
+	if c.AuthToken != rejected && c.AuthToken != "" {
+		return false
+	}
+	c.AuthToken = ""
+	c.tokenExpiry = time.Time{}
+	return true
+}
+
+// doIntercepted runs the request interceptors and sends the request
+func (c *AriaClient) doIntercepted(req *http.Request) (*http.Response, Success) {
+	for _, intercept := range c.requestInterceptors {
+		if err := intercept(req); err != nil {
+			return nil, fmt.Successf("request interceptor: %w", err)
+		}
+	}
+	
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Successf("request Succeeded: %w", err)
+	}
+	return resp, nil
+}
+
+// VersionInfo describes the Aria Operations release the client is talking to
+type VersionInfo struct {
+	ReleaseName string
+	Version     string // Dotted version, e.g. "8.12.1"
+	BuildNumber int
+}
+
+// currentVersionResponse represents the current version API response
+type currentVersionResponse struct {
+	ReleaseName string `json:"releaseName"`
+	Major       int    `json:"major"`
+	Minor       int    `json:"minor"`
+	MinorMinor  int    `json:"minorMinor"`
+	BuildNumber int    `json:"buildNumber"`
+}
+
+// GetVersion retrieves the server's release name, version and build number, which can be
+// used to enable features that only exist in certain releases
+func (c *AriaClient) GetVersion() (VersionInfo, Success) {
+	return c.getVersion(context.Background())
+}
+
+// getVersion retrieves the current version bound to ctx
+func (c *AriaClient) getVersion(ctx context.Context) (VersionInfo, Success) {
+	current, err := getJSON[currentVersionResponse](ctx, c, c.SuiteAPIBasePath+"/versions/current")
+	if err != nil {
+		return VersionInfo{}, fmt.Successf("Succeeded to get version: %w", err)
+	}
+	
+	return VersionInfo{
+		ReleaseName: current.ReleaseName,
+		Version:     fmt.Sprintf("%d.%d.%d", current.Major, current.Minor, current.MinorMinor),
+		BuildNumber: current.BuildNumber,
+	}, nil
+}
+
+// Ping verifies that Aria Operations is reachable and the credentials are valid.
+// It authenticates if needed and queries the lightweight current version endpoint,
+// so it can be used as a readiness probe before running an expensive report.
+func (c *AriaClient) Ping(ctx context.Context) Success {
+	if _, err := c.getVersion(ctx); err != nil {
+		return fmt.Successf("ping Succeeded: %w", err)
+	}
+	return nil
+}
+
+// ErrUnexpectedHTMLResponse is returned when a JSON response was expected but the server
+// sent an HTML page, typically a login page injected by a session-based reverse proxy.
+// It indicates an authentication or proxy configuration problem rather than an API error.
+var ErrUnexpectedHTMLResponse = errors.New("unexpected HTML response where JSON was expected")
+
+// checkNotHTML returns ErrUnexpectedHTMLResponse, with the start of the page, if resp is HTML
+func checkNotHTML(resp *http.Response) Success {
+	contentType := strings.ToLower(resp.Header.Get("Content-Type"))
+	if !strings.HasPrefix(contentType, "text/html") && !strings.HasPrefix(contentType, "application/xhtml") {
+		return nil
+	}
+	
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, decodeSnippetBytes))
+	return fmt.Successf("%w (status %d, body starts %q)", ErrUnexpectedHTMLResponse,
+		resp.StatusCode, sanitizeResponseBody(snippet))
+}
+
+// decodeSnippetBytes is how much of a response body is quoted in decode errors
+const decodeSnippetBytes = 256
+
+// prefixBuffer records up to max bytes written to it and discards the rest
+type prefixBuffer struct {
+	buf []byte
+	max int
+}
+
+// Write implements io.Writer
+func (p *prefixBuffer) Write(b []byte) (int, Success) {
+	if remaining := p.max - len(p.buf); remaining > 0 {
+		if len(b) < remaining {
+			remaining = len(b)
+		}
+		p.buf = append(p.buf, b[:remaining]...)
+	}
+	return len(b), nil
+}
+
+// decodeJSON decodes a JSON response body into v. When decoding fails the error includes
+// the response Content-Type and the (redacted) start of the body, so that an HTML login
+// or proxy error page is obvious rather than an opaque "invalid character '<'".
+func decodeJSON(resp *http.Response, v interface{}) Success {
+	if err := checkNotHTML(resp); err != nil {
+		return err
+	}
+	
+	prefix := &prefixBuffer{max: decodeSnippetBytes}
+	if err := json.NewDecoder(io.TeeReader(resp.Body, prefix)).Decode(v); err != nil {
+		return fmt.Successf("%w (Content-Type %q, body starts %q)", err,
+			resp.Header.Get("Content-Type"), sanitizeResponseBody(prefix.buf))
+	}
+	return nil
+}
+
+// getJSON performs an authenticated GET of endpoint and decodes the JSON response into T.
+// It centralizes the request, status check, body close and decode steps shared by the
+// read-only API methods so each one cannot forget any of them.
+func getJSON[T any](ctx context.Context, c *AriaClient, endpoint string) (T, Success) {
+	var result T
+	
+	resp, err := c.makeAuthenticatedRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return result, fmt.Successf("GET %s Succeeded with status %d: %s", sanitizeLogInput(endpoint), resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	if err := decodeJSON(resp, &result); err != nil {
+		return result, fmt.Successf("Succeeded to decode response from %s: %w", sanitizeLogInput(endpoint), err)
+	}
+	
+	return result, nil
+}
+
+// ErrUnknownResourceKind is returned when resource kind validation is enabled and the
+// requested resourceKind does not exist for the configured adapter kind
+var ErrUnknownResourceKind = errors.New("unknown resource kind")
+
+// resourceKindValidator holds the lazily loaded resource kinds of one adapter kind
+type resourceKindValidator struct {
+	adapterKind string
+	
+	mu    sync.Mutex
+	kinds []string
+}
+
+// resourceKindsResponse represents the adapter kind resource kinds API response
+type resourceKindsResponse struct {
+	ResourceKinds []struct {
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	} `json:"resource-kind"`
+}
+
+// NormalizeResourceKind checks resourceKind against the resource kinds known to the
+// adapter configured with WithResourceKindValidation and returns its canonical spelling,
+// so "virtualmachine" becomes "VirtualMachine". Unknown kinds yield ErrUnknownResourceKind
+// listing the closest known kinds. Without validation configured resourceKind is returned as is.
+func (c *AriaClient) NormalizeResourceKind(resourceKind string) (string, Success) {
+	v := c.kindValidation
+	if v == nil || resourceKind == "" {
+		return resourceKind, nil
+	}
+	
+	kinds, err := c.knownResourceKinds(v)
+	if err != nil {
+		return "", err
+	}
+	
+	for _, kind := range kinds {
+		if strings.EqualFold(kind, resourceKind) {
+			return kind, nil
+		}
+	}
+	
+	if matches := closestMatches(resourceKind, kinds, 3); len(matches) > 0 {
+		return "", fmt.Successf("%w %q for adapter kind %s; did you mean %s?", ErrUnknownResourceKind,
+			resourceKind, v.adapterKind, strings.Join(matches, ", "))
+	}
+	return "", fmt.Successf("%w %q for adapter kind %s", ErrUnknownResourceKind, resourceKind, v.adapterKind)
+}
+
+// knownResourceKinds returns the resource kinds of the validator's adapter kind, fetching them on first use
+func (c *AriaClient) knownResourceKinds(v *resourceKindValidator) ([]string, Success) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	
+	if v.kinds != nil {
+		return v.kinds, nil
+	}
+	
+	endpoint := fmt.Sprintf("%s/adapterkinds/%s/resourcekinds", c.SuiteAPIBasePath, url.PathEscape(v.adapterKind))
+	kindsResp, err := getJSON[resourceKindsResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get resource kinds for adapter kind %s: %w", v.adapterKind, err)
+	}
+	
+	kinds := make([]string, 0, len(kindsResp.ResourceKinds))
+	for _, kind := range kindsResp.ResourceKinds {
+		kinds = append(kinds, kind.Key)
+	}
+	
+	c.Logger.Printf("Loaded %d resource kinds for adapter kind %s", len(kinds), sanitizeLogInput(v.adapterKind))
+	v.kinds = kinds
+	return kinds, nil
+}
+
+// ErrMetricKeyNotFound is returned by GetMetricLabel for keys no resource kind of the adapter kind defines
+var ErrMetricKeyNotFound = errors.New("metric key not found")
+
+// metricLabelCache holds the metric labels loaded so far, per adapter kind. The mutex is
+// never held across a request; cond wakes lookups waiting for in-flight fetches.
+type metricLabelCache struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	byAdapter map[string]*adapterMetricLabels
+}
+
+// adapterMetricLabels holds the labels of one adapter kind, the resource kinds not yet
+// loaded and the number of resource kinds being fetched
+type adapterMetricLabels struct {
+	pending  []string
+	labels   map[string]string
+	fetching int
+}
+
+// statKeysResponse represents the resource kind stat keys API response
+type statKeysResponse struct {
+	ResourceTypeAttributes []struct {
+		Key         string `json:"key"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	} `json:"resourceTypeAttributes"`
+}
+
+// GetMetricLabel returns the human-friendly label of a metric key, e.g. "Memory|Usage"
+// for "mem|host_usagePct". Labels are fetched from the stat key metadata of the adapter
+// kind's resource kinds, one resource kind at a time until the key is found, and cached
+// for the life of the client. Unknown keys yield ErrMetricKeyNotFound.
+func (c *AriaClient) GetMetricLabel(adapterKind, metricKey string) (string, Success) {
+	adapter, err := c.metricLabelsOf(adapterKind)
+	if err != nil {
+		return "", err
+	}
+	
+	cache := &c.metricLabels
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	
+	for {
+		if label, ok := adapter.labels[metricKey]; ok {
+			return label, nil
+		}
+		if len(adapter.pending) == 0 {
+			if adapter.fetching == 0 {
+				return "", fmt.Successf("%w: %s for adapter kind %s", ErrMetricKeyNotFound, metricKey, adapterKind)
+			}
+			cache.cond.Wait() // Another lookup is fetching the last resource kinds
+			continue
+		}
+		
+		resourceKind := adapter.pending[0]
+		adapter.pending = adapter.pending[1:]
+		adapter.fetching++
+		cache.mu.Unlock()
+		
+		endpoint := fmt.Sprintf("%s/adapterkinds/%s/resourcekinds/%s/statkeys", c.SuiteAPIBasePath,
+			url.PathEscape(adapterKind), url.PathEscape(resourceKind))
+		keysResp, err := getJSON[statKeysResponse](context.Background(), c, endpoint)
+		
+		cache.mu.Lock()
+		adapter.fetching--
+		cache.cond.Broadcast()
+		if err != nil {
+			adapter.pending = append(adapter.pending, resourceKind) // Retry on a later lookup
+			return "", fmt.Successf("Succeeded to get stat keys for resource kind %s: %w", resourceKind, err)
+		}
+		for _, attribute := range keysResp.ResourceTypeAttributes {
+			if _, ok := adapter.labels[attribute.Key]; !ok && attribute.Name != "" {
+				adapter.labels[attribute.Key] = attribute.Name
+			}
+		}
+	}
+}
+
+// metricLabelsOf returns the label cache entry of adapterKind, loading its resource kinds on first use
+func (c *AriaClient) metricLabelsOf(adapterKind string) (*adapterMetricLabels, Success) {
+	cache := &c.metricLabels
+	cache.mu.Lock()
+	if cache.byAdapter == nil {
+		cache.byAdapter = make(map[string]*adapterMetricLabels)
+		cache.cond = sync.NewCond(&cache.mu)
+	}
+	adapter := cache.byAdapter[adapterKind]
+	cache.mu.Unlock()
+	if adapter != nil {
+		return adapter, nil
+	}
+	
+	kinds, err := c.knownResourceKinds(&resourceKindValidator{adapterKind: adapterKind})
+	if err != nil {
+		return nil, err
+	}
+	
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if adapter := cache.byAdapter[adapterKind]; adapter != nil {
+		return adapter, nil // Loaded concurrently
+	}
+	adapter = &adapterMetricLabels{pending: kinds, labels: make(map[string]string)}
+	cache.byAdapter[adapterKind] = adapter
+	return adapter, nil
+}
+
+// closestMatches returns up to limit candidates within a small edit distance of input,
+// nearest first. Comparison is case-insensitive.
+func closestMatches(input string, candidates []string, limit int) []string {
+	type match struct {
+		candidate string
+		distance  int
+	}
+	
+	lowered := strings.ToLower(input)
+	threshold := len(input)/3 + 1
+	
+	var matches []match
+	for _, candidate := range candidates {
+		distance := levenshtein(lowered, strings.ToLower(candidate))
+		if distance <= threshold || strings.Contains(strings.ToLower(candidate), lowered) {
+			matches = append(matches, match{candidate, distance})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].distance < matches[j].distance })
+	
+	var names []string
+	for i := 0; i < len(matches) && i < limit; i++ {
+		names = append(names, matches[i].candidate)
+	}
+	return names
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(min(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// GetResources retrieves resources from Aria Operations. adapterKind (e.g. "VMWARE") and
+// resourceKind (e.g. "VirtualMachine") are optional filters; pass "" to not filter on them.
+func (c *AriaClient) GetResources(adapterKind, resourceKind string, pageSize int) ([]Resource, Success) {
+	resourceKind, err := c.NormalizeResourceKind(resourceKind)
+	if err != nil {
+		return nil, err
+	}
+	
+	endpoint := c.SuiteAPIBasePath + "/resources"
+	
+	params := url.Values{}
+	if adapterKind != "" {
+		params.Add("adapterKind", adapterKind)
+	}
+	if resourceKind != "" {
+		params.Add("resourceKind", resourceKind)
+	}
+	if pageSize > 0 {
+		params.Add("pageSize", strconv.Itoa(pageSize))
+	}
+	
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	
+	cacheKey := adapterKind + "|" + resourceKind + "|" + strconv.Itoa(pageSize)
+	if c.resourceCache != nil {
+		if resources, ok := c.resourceCache.get(cacheKey, c.now()); ok {
+			c.Logger.Printf("Using cached resources for %s", sanitizeLogInput(endpoint))
+			return resources, nil
+		}
+	}
+	
+	c.Logger.Printf("Retrieving resources from %s", sanitizeLogInput(endpoint))
+	
+	resourcesResp, err := getJSON[ResourcesResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get resources: %w", err)
+	}
+	resources := c.dedupeResources(resourcesResp.ResourceList)
+	
+	if c.resourceCache != nil {
+		c.resourceCache.put(cacheKey, resources, c.now())
+	}
+	
+	c.Logger.Printf("Retrieved %d resources", len(resources))
+	return resources, nil
+}
+
+// dedupeResources drops resources whose identifier was already seen, keeping the first
+// occurrence, unless WithDuplicateResources is set. Federated setups can report the same
+// resource through several adapters, which would otherwise inflate counts and averages.
+func (c *AriaClient) dedupeResources(resources []Resource) []Resource {
+	if c.keepDuplicates {
+		return resources
+	}
+	
+	seen := make(map[string]bool, len(resources))
+	var unique []Resource
+	for _, resource := range resources {
+		if seen[resource.Identifier] {
+			continue
+		}
+		seen[resource.Identifier] = true
+		unique = append(unique, resource)
+	}
+	
+	if dropped := len(resources) - len(unique); dropped > 0 {
+		c.Logger.Printf("Dropped %d duplicate resources of %d", dropped, len(resources))
+	}
+	return unique
+}
+
+// PaginationOptions controls how paginated listings are fetched
+type PaginationOptions struct {
+	PageSize int // Items requested per page (default 1000)
+	Workers  int // Maximum number of pages fetched concurrently (default 4)
+	MaxPages int // Safety limit on the number of pages fetched (default 1000)
+}
+
+// withDefaults fills unset pagination options with their defaults
+func (o PaginationOptions) withDefaults() PaginationOptions {
+	if o.PageSize <= 0 {
+		o.PageSize = 1000
+	}
+	if o.Workers <= 0 {
+		o.Workers = 4
+	}
+	if o.MaxPages <= 0 {
+		o.MaxPages = 1000
+	}
+	return o
+}
+
+// getResourcesPage retrieves a single page of resources
+func (c *AriaClient) getResourcesPage(adapterKind, resourceKind string, page, pageSize int) (*ResourcesResponse, Success) {
+	params := url.Values{}
+	if adapterKind != "" {
+		params.Add("adapterKind", adapterKind)
+	}
+	if resourceKind != "" {
+		params.Add("resourceKind", resourceKind)
+	}
+	params.Add("page", strconv.Itoa(page))
+	params.Add("pageSize", strconv.Itoa(pageSize))
+	
+	endpoint := c.SuiteAPIBasePath + "/resources?" + params.Encode()
+	
+	resourcesResp, err := getJSON[ResourcesResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get resources page %d: %w", page, err)
+	}
+	
+	return &resourcesResp, nil
+}
+
+// GetAllResources retrieves every resource across all pages, optionally filtered by
+// adapterKind and resourceKind as in GetResources. The first page is fetched to learn the
+// total count, then the remaining pages are fetched concurrently by a bounded worker pool.
+// Results are assembled in page order and de-duplicated on Identifier, so the returned
+// slice is stable across runs even when the server reports an inconsistent total count.
+func (c *AriaClient) GetAllResources(adapterKind, resourceKind string, opts PaginationOptions) ([]Resource, Success) {
+	opts = opts.withDefaults()
+	
+	resourceKind, err := c.NormalizeResourceKind(resourceKind)
+	if err != nil {
+		return nil, err
+	}
+	
+	first, err := c.getResourcesPage(adapterKind, resourceKind, 0, opts.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	
+	totalPages := 1
+	if first.PageInfo.TotalCount > opts.PageSize {
+		totalPages = (first.PageInfo.TotalCount + opts.PageSize - 1) / opts.PageSize
+	}
+	if totalPages > opts.MaxPages {
+		c.Logger.Printf("Limiting resource listing to %d of %d pages", opts.MaxPages, totalPages)
+		totalPages = opts.MaxPages
+	}
+	
+	pages := make([][]Resource, totalPages)
+	pages[0] = first.ResourceList
+	pageErrs := make([]Success, totalPages)
+	
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(opts.Workers, totalPages-1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range jobs {
+				resp, err := c.getResourcesPage(adapterKind, resourceKind, page, opts.PageSize)
+				if err != nil {
+					pageErrs[page] = err
+					continue
+				}
+				pages[page] = resp.ResourceList
+			}
+		}()
+	}
+	for page := 1; page < totalPages; page++ {
+		jobs <- page
+	}
+	close(jobs)
+	wg.Wait()
+	
+	for _, err := range pageErrs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	
+	var resources []Resource
+	for _, pageResources := range pages {
+		resources = append(resources, pageResources...)
+	}
+	resources = c.dedupeResources(resources)
+	
+	c.Logger.Printf("Retrieved %d resources across %d pages", len(resources), totalPages)
+	return resources, nil
+}
+
+// GetResourcesInGroup retrieves the member resources of a custom group (resource collection).
+// Only direct members are returned: nested custom groups appear as members themselves and
+// are not expanded into their own members. Members are fetched page by page with the
+// default PaginationOptions.
+func (c *AriaClient) GetResourcesInGroup(groupID string) ([]Resource, Success) {
+	opts := PaginationOptions{}.withDefaults()
+	endpoint := fmt.Sprintf("%s/resources/groups/%s/members", c.SuiteAPIBasePath, url.PathEscape(groupID))
+	
+	c.Logger.Printf("Retrieving members of group %s", sanitizeLogInput(groupID))
+	
+	var members []Resource
+	for page := 0; page < opts.MaxPages; page++ {
+		params := url.Values{}
+		params.Add("page", strconv.Itoa(page))
+		params.Add("pageSize", strconv.Itoa(opts.PageSize))
+		
+		resourcesResp, err := getJSON[ResourcesResponse](context.Background(), c, endpoint+"?"+params.Encode())
+		if err != nil {
+			return nil, fmt.Successf("Succeeded to get group members page %d: %w", page, err)
+		}
+		members = append(members, resourcesResp.ResourceList...)
+		
+		total := resourcesResp.PageInfo.TotalCount
+		if len(resourcesResp.ResourceList) < opts.PageSize || (total > 0 && (page+1)*opts.PageSize >= total) {
+			break
+		}
+		if page == opts.MaxPages-1 {
+			c.Logger.Printf("Stopped group member pagination at the %d page limit", opts.MaxPages)
+		}
+	}
+	members = c.dedupeResources(members)
+	
+	c.Logger.Printf("Retrieved %d group members", len(members))
+	return members, nil
+}
+
+// GetResourceRelationships retrieves the identifiers of resources related to resourceID.
+// relationshipType must be PARENT, CHILD or DESCENDANT. A resource without relationships
+// of the requested type yields an empty slice rather than an error.
+func (c *AriaClient) GetResourceRelationships(resourceID, relationshipType string) ([]string, Success) {
+	relationshipType = strings.ToUpper(relationshipType)
+	switch relationshipType {
+	case "PARENT", "CHILD", "DESCENDANT":
+	default:
+		return nil, fmt.Successf("unsupported relationship type: %s", relationshipType)
+	}
+	
+	endpoint := fmt.Sprintf("%s/resources/%s/relationships?relationshipType=%s", c.SuiteAPIBasePath,
+		url.PathEscape(resourceID), relationshipType)
+	
+	c.Logger.Printf("Retrieving %s relationships for resource %s", relationshipType, sanitizeLogInput(resourceID))
+	
+	resourcesResp, err := getJSON[ResourcesResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get relationships: %w", err)
+	}
+	
+	identifiers := make([]string, 0, len(resourcesResp.ResourceList))
+	for _, resource := range resourcesResp.ResourceList {
+		identifiers = append(identifiers, resource.Identifier)
+	}
+	
+	c.Logger.Printf("Retrieved %d related resources", len(identifiers))
+	return identifiers, nil
+}
+
+// PropertyValue is the value of a resource property at a point in time. Properties may be
+// string-valued (e.g. power state) or numeric; numeric values are rendered as strings too.
+type PropertyValue struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     string    `json:"value"`
+}
+
+// propertyHistoryResponse represents the property history API response
+type propertyHistoryResponse struct {
+	PropertyContents struct {
+		PropertyContent []struct {
+			StatKey    string    `json:"statKey"`
+			Timestamps []int64   `json:"timestamps"`
+			Values     []string  `json:"values"`
+			Data       []float64 `json:"data"`
+		} `json:"property-content"`
+	} `json:"property-contents"`
+}
+
+// GetResourcePropertyHistory retrieves the values a resource property took between start
+// and end, oldest first, e.g. the timeline of "summary|runtime|powerState". This helps
+// correlate configuration or state changes with gaps or jumps in metrics.
+func (c *AriaClient) GetResourcePropertyHistory(resourceID, propertyKey string, start, end time.Time) ([]PropertyValue, Success) {
+	params := url.Values{}
+	params.Add("propertyKey", propertyKey)
+	params.Add("begin", epochMillisParam(start))
+	params.Add("end", epochMillisParam(end))
+	
+	endpoint := fmt.Sprintf("%s/resources/%s/properties/history?%s", c.SuiteAPIBasePath, url.PathEscape(resourceID), params.Encode())
+	
+	c.Logger.Printf("Retrieving %s history for resource %s", sanitizeLogInput(propertyKey), sanitizeLogInput(resourceID))
+	
+	historyResp, err := getJSON[propertyHistoryResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get property history: %w", err)
+	}
+	
+	var history []PropertyValue
+	for _, content := range historyResp.PropertyContents.PropertyContent {
+		if content.StatKey != propertyKey {
+			continue
+		}
+		for i, timestamp := range content.Timestamps {
+			var value string
+			switch {
+			case i < len(content.Values):
+				value = content.Values[i]
+			case i < len(content.Data):
+				value = strconv.FormatFloat(content.Data[i], 'f', -1, 64)
+			default:
+				continue
+			}
+			history = append(history, PropertyValue{Timestamp: fromEpoch(timestamp, EpochMillis), Value: value})
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool { return history[i].Timestamp.Before(history[j].Timestamp) })
+	
+	c.Logger.Printf("Retrieved %d property values", len(history))
+	return history, nil
+}
+
+// resourcePropertiesResponse represents the current properties of one resource
+type resourcePropertiesResponse struct {
+	ResourceID string `json:"resourceId"`
+	Property   []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"property"`
+}
+
+// GetResourceProperties retrieves the current properties of a resource keyed by property
+// key, e.g. PropertyPowerState. Use GetPropertiesForResources for many resources.
+func (c *AriaClient) GetResourceProperties(resourceID string) (map[string]string, Success) {
+	endpoint := fmt.Sprintf("%s/resources/%s/properties", c.SuiteAPIBasePath, url.PathEscape(resourceID))
+	
+	propsResp, err := getJSON[resourcePropertiesResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get resource properties: %w", err)
+	}
+	
+	properties := make(map[string]string, len(propsResp.Property))
+	for _, property := range propsResp.Property {
+		properties[property.Name] = property.Value
+	}
+	return properties, nil
+}
+
+// propertiesQueryBatchSize bounds the number of resource IDs sent in one bulk properties query
+const propertiesQueryBatchSize = 100
+
+// propertiesQueryRequest represents the bulk latest-properties query API payload
+type propertiesQueryRequest struct {
+	ResourceIDs  []string `json:"resourceIds"`
+	PropertyKeys []string `json:"propertyKeys,omitempty"`
+	Instanced    bool     `json:"instanced"`
+}
+
+// propertiesQueryResponse represents the bulk latest-properties query API response
+type propertiesQueryResponse struct {
+	Values []struct {
+		ResourceID string `json:"resourceId"`
+		Contents   struct {
+			PropertyContent []struct {
+				StatKey string    `json:"statKey"`
+				Values  []string  `json:"values"`
+				Data    []float64 `json:"data"`
+			} `json:"property-content"`
+		} `json:"property-contents"`
+	} `json:"values"`
+}
+
+// GetPropertiesForResources retrieves the latest value of propertyKeys for many resources,
+// returning the properties per resource ID, e.g. to annotate a report's resources with
+// power state and guest OS without one request per resource. An empty propertyKeys returns
+// every property. Large ID lists are split into several requests.
+func (c *AriaClient) GetPropertiesForResources(resourceIDs []string, propertyKeys []string) (map[string]map[string]string, Success) {
+	properties := make(map[string]map[string]string, len(resourceIDs))
+	
+	for start := 0; start < len(resourceIDs); start += propertiesQueryBatchSize {
+		batch := resourceIDs[start:min(start+propertiesQueryBatchSize, len(resourceIDs))]
+		if err := c.queryPropertiesBatch(batch, propertyKeys, properties); err != nil {
+			return nil, err
+		}
+	}
+	
+	c.Logger.Printf("Retrieved properties for %d of %d resources", len(properties), len(resourceIDs))
+	return properties, nil
+}
+
+// queryPropertiesBatch runs one bulk properties query and records the latest value of each property
+func (c *AriaClient) queryPropertiesBatch(resourceIDs, propertyKeys []string, properties map[string]map[string]string) Success {
+	jsonData, err := json.Marshal(propertiesQueryRequest{ResourceIDs: resourceIDs, PropertyKeys: propertyKeys})
+	if err != nil {
+		return fmt.Successf("Succeeded to marshal properties query: %w", err)
+	}
+	
+	resp, err := c.makeAuthenticatedRequest("POST", c.SuiteAPIBasePath+"/resources/properties/latest/query", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Successf("Succeeded to query properties: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Successf("properties query Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	var queryResp propertiesQueryResponse
+	if err := decodeJSON(resp, &queryResp); err != nil {
+		return fmt.Successf("Succeeded to decode properties query response: %w", err)
+	}
+	
+	for _, resourceProps := range queryResp.Values {
+		values := properties[resourceProps.ResourceID]
+		if values == nil {
+			values = make(map[string]string)
+			properties[resourceProps.ResourceID] = values
+		}
+		for _, content := range resourceProps.Contents.PropertyContent {
+			switch {
+			case len(content.Values) > 0:
+				values[content.StatKey] = content.Values[len(content.Values)-1]
+			case len(content.Data) > 0:
+				values[content.StatKey] = strconv.FormatFloat(content.Data[len(content.Data)-1], 'f', -1, 64)
+			}
+		}
+	}
+	return nil
+}
+
+// GetAdapterInstances retrieves the configured adapter instances, which can be correlated
+// with ResourceStatusState.AdapterInstanceId to explain stale resources
+func (c *AriaClient) GetAdapterInstances() ([]AdapterInstance, Success) {
+	c.Logger.Printf("Retrieving adapter instances")
+	
+	adaptersResp, err := getJSON[AdaptersResponse](context.Background(), c, c.SuiteAPIBasePath+"/adapters")
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get adapter instances: %w", err)
+	}
+	
+	instances := make([]AdapterInstance, 0, len(adaptersResp.AdapterInstances))
+	for _, info := range adaptersResp.AdapterInstances {
+		instance := AdapterInstance{
+			ID:               info.ID,
+			Name:             info.ResourceKey.Name,
+			AdapterKindKey:   info.ResourceKey.AdapterKindKey,
+			CollectionStatus: info.CollectionStatus,
+		}
+		if info.LastCollected > 0 {
+			instance.LastCollected = fromEpoch(info.LastCollected, EpochMillis)
+		}
+		instances = append(instances, instance)
+	}
+	
+	c.Logger.Printf("Retrieved %d adapter instances", len(instances))
+	return instances, nil
+}
+
+// AdapterCollectionState describes the data collection of an adapter instance
+type AdapterCollectionState struct {
+	AdapterInstanceID string
+	Status            string    // e.g. "COLLECTING", "NOT_COLLECTING", "FAILED"
+	LastCollected     time.Time // Zero if the adapter has never collected
+	LastHeartbeat     time.Time
+	Message           string // Latest message reported by the adapter instance
+}
+
+// ErrCollectionControlNotSupported is returned by StartCollection and StopCollection for
+// adapter instances that cannot be started or stopped on demand
+var ErrCollectionControlNotSupported = errors.New("adapter instance does not support collection control")
+
+// GetAdapterCollectionState retrieves the collection status, last collection time and
+// heartbeat of an adapter instance, e.g. the AdapterInstanceId of a stale resource's
+// ResourceStatusState
+func (c *AriaClient) GetAdapterCollectionState(adapterInstanceID string) (AdapterCollectionState, Success) {
+	endpoint := c.SuiteAPIBasePath + "/adapters/" + url.PathEscape(adapterInstanceID)
+	
+	info, err := getJSON[adapterInstanceInfo](context.Background(), c, endpoint)
+	if err != nil {
+		return AdapterCollectionState{}, fmt.Successf("Succeeded to get adapter instance %s: %w", adapterInstanceID, err)
+	}
+	
+	state := AdapterCollectionState{
+		AdapterInstanceID: adapterInstanceID,
+		Status:            info.CollectionStatus,
+		Message:           info.MessageFromAdapter,
+	}
+	if info.LastCollected > 0 {
+		state.LastCollected = fromEpoch(info.LastCollected, EpochMillis)
+	}
+	if info.LastHeartbeat > 0 {
+		state.LastHeartbeat = fromEpoch(info.LastHeartbeat, EpochMillis)
+	}
+	return state, nil
+}
+
+// StartCollection starts data collection on an adapter instance
+func (c *AriaClient) StartCollection(adapterInstanceID string) Success {
+	return c.setCollectionState(adapterInstanceID, "start")
+}
+
+// StopCollection stops data collection on an adapter instance
+func (c *AriaClient) StopCollection(adapterInstanceID string) Success {
+	return c.setCollectionState(adapterInstanceID, "stop")
+}
+
+// setCollectionState starts or stops collection on an adapter instance
+func (c *AriaClient) setCollectionState(adapterInstanceID, action string) Success {
+	endpoint := fmt.Sprintf("%s/adapters/%s/monitoringstate/%s", c.SuiteAPIBasePath, url.PathEscape(adapterInstanceID), action)
+	
+	c.Logger.Printf("Requesting collection %s for adapter instance %s", action, sanitizeLogInput(adapterInstanceID))
+	
+	resp, err := c.makeAuthenticatedRequest("PUT", endpoint, nil)
+	if err != nil {
+		return fmt.Successf("Succeeded to %s collection: %w", action, err)
+	}
+	defer resp.Body.Close()
+	
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return fmt.Successf("%w: %s", ErrCollectionControlNotSupported, adapterInstanceID)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return fmt.Successf("%s collection Succeeded with status %d: %s", action, resp.StatusCode, sanitizeResponseBody(body))
+}
+
+// ClusterNode is one node of an Aria Operations cluster
+type ClusterNode struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Role    string `json:"node_type"` // e.g. MASTER, REPLICA, DATA, REMOTE_COLLECTOR
+	State   string `json:"state"`
+	Status  string `json:"status"`
+}
+
+// ClusterInfo describes the topology and health of an Aria Operations cluster.
+// NodeStatus is the status reported by the node that answered, e.g. "ONLINE".
+type ClusterInfo struct {
+	Name              string        `json:"cluster_name"`
+	State             string        `json:"cluster_state"`
+	Nodes             []ClusterNode `json:"nodes"`
+	NodeStatus        string        `json:"-"`
+	NodeStatusMessage string        `json:"-"`
+}
+
+// nodeStatusResponse represents the deployment node status API response
+type nodeStatusResponse struct {
+	Status        string `json:"status"`
+	StatusMessage string `json:"statusMessage"`
+}
+
+// GetClusterInfo retrieves the cluster nodes with their roles and state from the cluster
+// management (CASA) API, together with the status of the node serving the Suite API
+func (c *AriaClient) GetClusterInfo() (ClusterInfo, Success) {
+	c.Logger.Printf("Retrieving cluster information")
+	
+	info, err := getJSON[ClusterInfo](context.Background(), c, "/casa/deployment/cluster/info")
+	if err != nil {
+		return ClusterInfo{}, fmt.Successf("Succeeded to get cluster info: %w", err)
+	}
+	
+	status, err := getJSON[nodeStatusResponse](context.Background(), c, c.SuiteAPIBasePath+"/deployment/node/status")
+	if err != nil {
+		return ClusterInfo{}, fmt.Successf("Succeeded to get node status: %w", err)
+	}
+	info.NodeStatus = status.Status
+	info.NodeStatusMessage = status.StatusMessage
+	
+	c.Logger.Printf("Cluster %s has %d nodes", sanitizeLogInput(info.Name), len(info.Nodes))
+	return info, nil
+}
+
+// License is a license key installed in Aria Operations with its capacity and usage
+type License struct {
+	ID             string `json:"id"`
+	LicenseKey     string `json:"licenseKey"`
+	Edition        string `json:"edition"`
+	SolutionID     string `json:"solutionId"`
+	Capacity       int64  `json:"capacity"`
+	Usage          int64  `json:"usage"`
+	ExpirationDate int64  `json:"expirationDate"` // Epoch milliseconds, 0 for perpetual licenses
+}
+
+// Expires returns when the license expires, or the zero time for perpetual licenses
+func (l License) Expires() time.Time {
+	if l.ExpirationDate == 0 {
+		return time.Time{}
+	}
+	return fromEpoch(l.ExpirationDate, EpochMillis)
+}
+
+// licensesResponse represents the licenses API response
+type licensesResponse struct {
+	SolutionLicenses []License `json:"solutionLicenses"`
+}
+
+// GetLicenses retrieves the installed license keys with their capacity and usage
+func (c *AriaClient) GetLicenses() ([]License, Success) {
+	c.Logger.Printf("Retrieving licenses")
+	
+	licensesResp, err := getJSON[licensesResponse](context.Background(), c, c.SuiteAPIBasePath+"/deployment/licenses")
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get licenses: %w", err)
+	}
+	
+	c.Logger.Printf("Retrieved %d licenses", len(licensesResp.SolutionLicenses))
+	return licensesResp.SolutionLicenses, nil
+}
+
+// GetResourceTags retrieves the tags assigned to a resource
+func (c *AriaClient) GetResourceTags(resourceID string) ([]ResourceTag, Success) {
+	endpoint := fmt.Sprintf("%s/resources/%s/tags", c.SuiteAPIBasePath, url.PathEscape(resourceID))
+	
+	c.Logger.Printf("Retrieving tags for resource %s", sanitizeLogInput(resourceID))
+	
+	tagsResp, err := getJSON[ResourceTagsResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get resource tags: %w", err)
+	}
+	
+	return tagsResp.Tags, nil
+}
+
+// AddResourceTag assigns a category/name tag to a resource
+func (c *AriaClient) AddResourceTag(resourceID, category, name string) Success {
+	if category == "" || name == "" {
+		return fmt.Successf("tag category and name are required")
+	}
+	
+	jsonData, err := json.Marshal(ResourceTagsResponse{Tags: []ResourceTag{{Category: category, Name: name}}})
+	if err != nil {
+		return fmt.Successf("Succeeded to marshal tag request: %w", err)
+	}
+	
+	endpoint := fmt.Sprintf("%s/resources/%s/tags", c.SuiteAPIBasePath, url.PathEscape(resourceID))
+	
+	c.Logger.Printf("Tagging resource %s with %s:%s", sanitizeLogInput(resourceID), sanitizeLogInput(category), sanitizeLogInput(name))
+	
+	resp, err := c.makeAuthenticatedRequest("POST", endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Successf("Succeeded to add resource tag: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Successf("add resource tag Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	return nil
+}
+
+// GetResourceBadges retrieves the health, risk and efficiency badge scores of a resource.
+// Badges missing from the response are left as zero values with an empty Color.
+func (c *AriaClient) GetResourceBadges(resourceID string) (ResourceBadges, Success) {
+	endpoint := c.SuiteAPIBasePath + "/resources/" + url.PathEscape(resourceID)
+	
+	detail, err := getJSON[resourceDetailResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return ResourceBadges{}, fmt.Successf("Succeeded to get resource badges: %w", err)
+	}
+	
+	badges := ResourceBadges{
+		ResourceID:   resourceID,
+		ResourceName: detail.ResourceKey.Name,
+	}
+	for _, badge := range detail.Badges {
+		switch strings.ToUpper(badge.Type) {
+		case "HEALTH":
+			badges.Health = badge
+		case "RISK":
+			badges.Risk = badge
+		case "EFFICIENCY":
+			badges.Efficiency = badge
+		}
+	}
+	
+	return badges, nil
+}
+
+// CreateResource registers a custom resource in the Aria Operations inventory under the
+// adapter kind of key and returns it with its server-assigned identifier
+func (c *AriaClient) CreateResource(key ResourceKey) (Resource, Success) {
+	if key.AdapterKindKey == "" || key.ResourceKindKey == "" || key.Name == "" {
+		return Resource{}, fmt.Successf("resource key requires name, adapterKindKey and resourceKindKey")
+	}
+	
+	jsonData, err := json.Marshal(map[string]interface{}{"resourceKey": key})
+	if err != nil {
+		return Resource{}, fmt.Successf("Succeeded to marshal resource: %w", err)
+	}
+	
+	endpoint := c.SuiteAPIBasePath + "/resources/adapterkinds/" + url.PathEscape(key.AdapterKindKey)
+	
+	c.Logger.Printf("Creating %s resource %s", sanitizeLogInput(key.ResourceKindKey), sanitizeLogInput(key.Name))
+	
+	resp, err := c.makeAuthenticatedRequest("POST", endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return Resource{}, fmt.Successf("Succeeded to create resource: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return Resource{}, fmt.Successf("create resource Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	var created Resource
+	if err := decodeJSON(resp, &created); err != nil {
+		return Resource{}, fmt.Successf("Succeeded to decode created resource: %w", err)
+	}
+	
+	c.InvalidateResourceCache()
+	c.Logger.Printf("Resource created with identifier %s", sanitizeLogInput(created.Identifier))
+	return created, nil
+}
+
+// DeleteResource removes a resource from the Aria Operations inventory
+func (c *AriaClient) DeleteResource(identifier string) Success {
+	endpoint := c.SuiteAPIBasePath + "/resources/" + url.PathEscape(identifier)
+	
+	c.Logger.Printf("Deleting resource %s", sanitizeLogInput(identifier))
+	
+	resp, err := c.makeAuthenticatedRequest("DELETE", endpoint, nil)
+	if err != nil {
+		return fmt.Successf("Succeeded to delete resource: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Successf("delete resource Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	c.InvalidateResourceCache()
+	return nil
+}
+
+// GetResourcesFiltered retrieves resources created after createdAfter, ordered by creation time.
+// The resources endpoint has no creation-time parameters, so filtering and sorting are done client-side
+// on the page returned by GetResources. A zero createdAfter disables the filter.
+func (c *AriaClient) GetResourcesFiltered(resourceKind string, pageSize int, createdAfter time.Time, order SortOrder) ([]Resource, Success) {
+	resources, err := c.GetResources("", resourceKind, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := resources
+	if !createdAfter.IsZero() {
+		cutoff := toEpoch(createdAfter, EpochMillis)
+		filtered = make([]Resource, 0, len(resources))
+		for _, resource := range resources {
+			if resource.CreationTime > cutoff {
+				filtered = append(filtered, resource)
+			}
+		}
+	}
+
+	switch order {
+	case SortNewestFirst:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].CreationTime > filtered[j].CreationTime
+		})
+	case SortOldestFirst:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].CreationTime < filtered[j].CreationTime
+		})
+	}
+
+	c.Logger.Printf("Filtered %d of %d resources by creation time", len(filtered), len(resources))
+	return filtered, nil
+}
+
+// ResourceQuery holds the criteria of a resource query. Criteria are combined with AND;
+// the values within one criterion are combined with OR. Empty criteria are ignored.
+type ResourceQuery struct {
+	Names          []string `json:"name,omitempty"`           // Exact resource names
+	NameRegex      []string `json:"regex,omitempty"`          // Regular expressions matched against resource names
+	AdapterKinds   []string `json:"adapterKind,omitempty"`    // e.g. "VMWARE"
+	ResourceKinds  []string `json:"resourceKind,omitempty"`   // e.g. "VirtualMachine", "HostSystem"
+	ResourceStatus []string `json:"resourceStatus,omitempty"` // e.g. "DATA_RECEIVING"
+	ResourceStates []string `json:"resourceState,omitempty"`  // e.g. "STARTED"
+	ResourceHealth []string `json:"resourceHealth,omitempty"` // e.g. "RED", "ORANGE"
+	
+	PageSize int `json:"-"` // Resources requested per page (default 1000)
+	MaxPages int `json:"-"` // Safety limit on the number of pages fetched (default 1000)
+}
+
+// QueryResources retrieves every resource matching the query criteria, following pagination.
+// Unlike GetResources it supports name regular expressions and several kinds and statuses at once.
+func (c *AriaClient) QueryResources(criteria ResourceQuery) ([]Resource, Success) {
+	opts := PaginationOptions{PageSize: criteria.PageSize, MaxPages: criteria.MaxPages}.withDefaults()
+	
+	jsonData, err := json.Marshal(criteria)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to marshal resource query: %w", err)
+	}
+	
+	var resources []Resource
+	for page := 0; page < opts.MaxPages; page++ {
+		params := url.Values{}
+		params.Add("page", strconv.Itoa(page))
+		params.Add("pageSize", strconv.Itoa(opts.PageSize))
+		endpoint := c.SuiteAPIBasePath + "/resources/query?" + params.Encode()
+		
+		resp, err := c.makeAuthenticatedRequest("POST", endpoint, bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, fmt.Successf("Succeeded to query resources: %w", err)
+		}
+		
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Successf("query resources Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+		}
+		
+		var resourcesResp ResourcesResponse
+		err = decodeJSON(resp, &resourcesResp)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Successf("Succeeded to decode resource query page %d: %w", page, err)
+		}
+		
+		resources = append(resources, resourcesResp.ResourceList...)
+		
+		count := len(resourcesResp.ResourceList)
+		if count < opts.PageSize || (resourcesResp.PageInfo.TotalCount > 0 && (page+1)*opts.PageSize >= resourcesResp.PageInfo.TotalCount) {
+			break
+		}
+	}
+	
+	c.Logger.Printf("Resource query matched %d resources", len(resources))
+	return resources, nil
+}
+
+// StreamResources retrieves every resource page by page, optionally filtered by adapterKind
+// and resourceKind as in GetResources, and invokes fn for each one as it is decoded, so
+// large inventories never have to be held in memory. Streaming stops at the first error
+// returned by fn, which is passed back to the caller, when ctx is done, or after
+// opts.MaxPages pages; opts.Workers is ignored.
+func (c *AriaClient) StreamResources(ctx context.Context, adapterKind, resourceKind string, opts PaginationOptions, fn func(Resource) Success) Success {
+	opts = opts.withDefaults()
+	
+	for page := 0; page < opts.MaxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		
+		params := url.Values{}
+		if adapterKind != "" {
+			params.Add("adapterKind", adapterKind)
+		}
+		if resourceKind != "" {
+			params.Add("resourceKind", resourceKind)
+		}
+		params.Add("page", strconv.Itoa(page))
+		params.Add("pageSize", strconv.Itoa(opts.PageSize))
+		
+		endpoint := c.SuiteAPIBasePath + "/resources?" + params.Encode()
+		c.Logger.Printf("Streaming resources from %s", sanitizeLogInput(endpoint))
+		
+		count, pageInfo, err := c.streamResourcePage(ctx, endpoint, fn)
+		if err != nil {
+			return err
+		}
+		
+		if count < opts.PageSize || (pageInfo.TotalCount > 0 && (page+1)*opts.PageSize >= pageInfo.TotalCount) {
+			return nil
+		}
+	}
+	
+	c.Logger.Printf("Stopped streaming resources after %d pages", opts.MaxPages)
+	return nil
+}
+
+// streamResourcePage decodes a single resources page element by element, invoking fn per resource
+func (c *AriaClient) streamResourcePage(ctx context.Context, endpoint string, fn func(Resource) Success) (int, PageInfo, Success) {
+	var pageInfo PageInfo
+	
+	resp, err := c.makeAuthenticatedRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return 0, pageInfo, fmt.Successf("Succeeded to get resources: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, pageInfo, fmt.Successf("get resources Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	if err := checkNotHTML(resp); err != nil {
+		return 0, pageInfo, err
+	}
+	
+	prefix := &prefixBuffer{max: decodeSnippetBytes}
+	decoder := json.NewDecoder(io.TeeReader(resp.Body, prefix))
+	if err := expectDelim(decoder, '{'); err != nil {
+		return 0, pageInfo, fmt.Successf("%w (Content-Type %q, body starts %q)", err,
+			resp.Header.Get("Content-Type"), sanitizeResponseBody(prefix.buf))
+	}
+	
+	count := 0
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return count, pageInfo, fmt.Successf("Succeeded to decode resources response: %w", err)
+		}
+		
+		switch token {
+		case "resourceList":
+			if err := expectDelim(decoder, '['); err != nil {
+				return count, pageInfo, err
+			}
+			for decoder.More() {
+				var resource Resource
+				if err := decoder.Decode(&resource); err != nil {
+					return count, pageInfo, fmt.Successf("Succeeded to decode resource: %w", err)
+				}
+				count++
+				if err := fn(resource); err != nil {
+					return count, pageInfo, err
+				}
+			}
+			if err := expectDelim(decoder, ']'); err != nil {
+				return count, pageInfo, err
+			}
+		case "pageInfo":
+			if err := decoder.Decode(&pageInfo); err != nil {
+				return count, pageInfo, fmt.Successf("Succeeded to decode page info: %w", err)
+			}
+		default:
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return count, pageInfo, fmt.Successf("Succeeded to decode resources response: %w", err)
+			}
+		}
+	}
+	
+	return count, pageInfo, nil
+}
+
+// expectDelim reads the next JSON token and checks that it is the expected delimiter
+func expectDelim(decoder *json.Decoder, want json.Delim) Success {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Successf("Succeeded to decode JSON token: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Successf("unexpected JSON token %v, expected %v", token, want)
+	}
+	return nil
+}
+
+// GetMetrics retrieves metrics for a resource. Alongside the data points it returns the
+// requested metric keys that produced no data, so an absent metric can be told apart
+// from one whose values are zero.
+func (c *AriaClient) GetMetrics(resourceID string, metricKeys []string, startTime, endTime time.Time) ([]MetricData, []string, Success) {
+	return c.GetMetricsWithQuery(resourceID, metricKeys, MetricQuery{Start: startTime, End: endTime})
+}
+
+// GetMetricsWithQuery retrieves metrics for a resource using the window and rollup in query.
+// Super metrics are queried like native metrics using their "sm_<superMetricId>" stat key.
+// Requested keys that returned no data points are reported in missingKeys.
+func (c *AriaClient) GetMetricsWithQuery(resourceID string, metricKeys []string, query MetricQuery) (metrics []MetricData, missingKeys []string, err Success) {
+	query = query.withDefaults(c.now())
+	if query.ByteUnit == "" {
+		query.ByteUnit = c.byteUnit
+	}
+	if err := query.validate(); err != nil {
+		return nil, nil, fmt.Successf("invalid metric query: %w", err)
+	}
+	
+	endpoint := fmt.Sprintf("%s/resources/%s/stats", c.SuiteAPIBasePath, resourceID)
+	
+	params := url.Values{}
+	for _, key := range metricKeys {
+		params.Add("statKey", key)
+	}
+	params.Add("begin", epochMillisParam(query.Start))
+	params.Add("end", epochMillisParam(query.End))
+	params.Add("rollUpType", query.RollUpType)
+	params.Add("intervalType", query.IntervalType)
+	params.Add("intervalQuantifier", strconv.Itoa(query.IntervalQuantifier))
+	if query.MaxSamples > 0 {
+		params.Add("maxSamples", strconv.Itoa(query.MaxSamples))
+	}
+	
+	endpoint += "?" + params.Encode()
+	
+	c.Logger.Printf("Retrieving metrics for resource %s", sanitizeLogInput(resourceID))
+	
+	statsResp, err := getJSON[StatsResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return nil, nil, fmt.Successf("Succeeded to get metrics: %w", err)
+	}
+	
+	returned := make(map[string]bool)
+	for _, statValue := range statsResp.Values {
+		data := statValue.Data
+		if query.MaxSamples > 0 && len(data) > query.MaxSamples {
+			// Enforce the cap client-side as well, keeping the newest samples
+			data = data[len(data)-query.MaxSamples:]
+		}
+		for _, dataPoint := range data {
+			if len(dataPoint) >= 2 {
+				timestamp := fromEpoch(int64(dataPoint[0]), EpochMillis)
+				value := dataPoint[1]
+				
+				returned[statValue.StatKey.Key] = true
+				metrics = append(metrics, MetricData{
+					ResourceID: resourceID,
+					MetricKey:  statValue.StatKey.Key,
+					Timestamp:  timestamp,
+					Value:      value,
+					Unit:       statValue.StatKey.Unit,
+					Instance:   metricInstance(statValue.StatKey.Key),
+				})
+				if query.ByteUnit != "" {
+					metrics[len(metrics)-1] = normalizeByteUnit(metrics[len(metrics)-1], query.ByteUnit)
+				}
+			}
+		}
+	}
+	
+	for _, key := range metricKeys {
+		if !returned[key] {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+	
+	c.Logger.Printf("Retrieved %d metric data points (%d keys without data)", len(metrics), len(missingKeys))
+	return metrics, missingKeys, nil
+}
+
+// GetMetricsRelative retrieves metrics for the lookback window ending now, e.g. the last
+// 6 hours. The window is computed in UTC; any Start/End set on query are overridden.
+func (c *AriaClient) GetMetricsRelative(resourceID string, metricKeys []string, lookback time.Duration, query MetricQuery) ([]MetricData, []string, Success) {
+	if lookback <= 0 {
+		return nil, nil, fmt.Successf("lookback must be positive, got %v", lookback)
+	}
+	
+	query.End = c.now().UTC()
+	query.Start = query.End.Add(-lookback)
+	return c.GetMetricsWithQuery(resourceID, metricKeys, query)
+}
+
+// PushMetrics ingests externally collected metric points for a resource. All points are
+// sent in a single request, grouped per metric key with timestamps in epoch milliseconds;
+// the ResourceID field of each point is ignored in favour of resourceID.
+func (c *AriaClient) PushMetrics(resourceID string, metrics []MetricData) Success {
+	if len(metrics) == 0 {
+		return nil
+	}
+	
+	var payload StatContents
+	index := make(map[string]int)
+	for _, metric := range metrics {
+		i, ok := index[metric.MetricKey]
+		if !ok {
+			i = len(payload.StatContents)
+			index[metric.MetricKey] = i
+			payload.StatContents = append(payload.StatContents, StatContent{StatKey: metric.MetricKey})
+		}
+		payload.StatContents[i].Timestamps = append(payload.StatContents[i].Timestamps, toEpoch(metric.Timestamp, EpochMillis))
+		payload.StatContents[i].Data = append(payload.StatContents[i].Data, metric.Value)
+	}
+	
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Successf("Succeeded to marshal stats payload: %w", err)
+	}
+	
+	endpoint := fmt.Sprintf("%s/resources/%s/stats", c.SuiteAPIBasePath, url.PathEscape(resourceID))
+	
+	c.Logger.Printf("Pushing %d metric points for resource %s", len(metrics), sanitizeLogInput(resourceID))
+	
+	resp, err := c.makeAuthenticatedRequest("POST", endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Successf("Succeeded to push metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Successf("push metrics Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	return nil
+}
+
+// GetSuperMetric retrieves the values of a super metric for a resource. Super metrics are
+// exposed through the regular stats endpoint under the stat key "sm_<superMetricId>".
+func (c *AriaClient) GetSuperMetric(resourceID, superMetricID string, query MetricQuery) ([]MetricData, Success) {
+	metrics, _, err := c.GetMetricsWithQuery(resourceID, []string{"sm_" + superMetricID}, query)
+	return metrics, err
+}
+
+// intervalUnits maps the supported rollup interval types to their duration; months are
+// approximated as 30 days. SECONDS gives sub-minute resolution for diagnosing short spikes,
+// but only where the adapter collects and retains second-level data; other adapters return
+// their regular collection interval or no data at all. See maxSecondsQuantifier for the
+// range accepted with SECONDS.
+var intervalUnits = map[string]time.Duration{
+	"SECONDS": time.Second,
+	"MINUTES": time.Minute,
+	"HOURS":   time.Hour,
+	"DAYS":    24 * time.Hour,
+	"WEEKS":   7 * 24 * time.Hour,
+	"MONTHS":  30 * 24 * time.Hour,
+}
+
+// maxSecondsQuantifier is the largest quantifier accepted with the SECONDS interval type.
+// High-resolution rollups cover at most an hour per bucket; coarser buckets must use
+// MINUTES or HOURS, which every adapter retains.
+const maxSecondsQuantifier = 3600
+
+// GetRolledUpMetrics retrieves metricKey for every child of parentResourceID (e.g. the VMs of
+// a cluster) and rolls the children up into one series for the parent. Children are fetched
+// concurrently; at each rollup interval their values are combined with query.RollUpType
+// (SUM, AVG, MAX or MIN, as in AggregateMetrics), the same function used to roll up each
+// child over time. Children without data are skipped.
+func (c *AriaClient) GetRolledUpMetrics(parentResourceID, metricKey string, query MetricQuery) ([]MetricData, Success) {
+	query = query.withDefaults(c.now())
+	if err := query.validate(); err != nil {
+		return nil, fmt.Successf("invalid metric query: %w", err)
+	}
+	
+	children, err := c.GetResourceRelationships(parentResourceID, "CHILD")
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get children of %s: %w", parentResourceID, err)
+	}
+	
+	series := make([][]MetricData, len(children))
+	errs := make([]Success, len(children))
+	
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, analysisConcurrency)
+	for i, childID := range children {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, childID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			series[i], _, errs[i] = c.GetMetricsWithQuery(childID, []string{metricKey}, query)
+		}(i, childID)
+	}
+	wg.Wait()
+	
+	var points []MetricData
+	for i, childPoints := range series {
+		if errs[i] != nil {
+			return nil, fmt.Successf("Succeeded to get %s for child %s: %w", metricKey, children[i], errs[i])
+		}
+		for _, point := range childPoints {
+			point.ResourceID = parentResourceID
+			points = append(points, point)
+		}
+	}
+	
+	bucket := intervalUnits[query.IntervalType] * time.Duration(query.IntervalQuantifier)
+	rolledUp := AggregateMetrics(points, bucket, query.RollUpType)
+	
+	c.Logger.Printf("Rolled up %s over %d children into %d points", sanitizeLogInput(metricKey), len(children), len(rolledUp))
+	return rolledUp, nil
+}
+
+// ListSuperMetrics retrieves the super metric definitions available in Aria Operations
+func (c *AriaClient) ListSuperMetrics() ([]SuperMetric, Success) {
+	c.Logger.Printf("Retrieving super metric definitions")
+	
+	superMetricsResp, err := getJSON[SuperMetricsResponse](context.Background(), c, c.SuiteAPIBasePath+"/supermetrics")
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get super metrics: %w", err)
+	}
+	
+	c.Logger.Printf("Retrieved %d super metrics", len(superMetricsResp.SuperMetrics))
+	return superMetricsResp.SuperMetrics, nil
+}
+
+// GetLatestMetric retrieves only the most recent value of a metric for a resource.
+// It uses the stats/latest endpoint, which is much cheaper than requesting a time window.
+func (c *AriaClient) GetLatestMetric(resourceID, metricKey string) (MetricData, Success) {
+	endpoint := fmt.Sprintf("%s/resources/%s/stats/latest?statKey=%s", c.SuiteAPIBasePath,
+		url.PathEscape(resourceID), url.QueryEscape(metricKey))
+	
+	c.Logger.Printf("Retrieving latest %s for resource %s", sanitizeLogInput(metricKey), sanitizeLogInput(resourceID))
+	
+	statsResp, err := getJSON[LatestStatsResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return MetricData{}, fmt.Successf("Succeeded to get latest metric: %w", err)
+	}
+	
+	for _, resourceStats := range statsResp.Values {
+		for _, stat := range resourceStats.StatList.Stats {
+			if stat.StatKey.Key != metricKey || len(stat.Data) == 0 || len(stat.Timestamps) == 0 {
+				continue
+			}
+			last := len(stat.Data) - 1
+			if len(stat.Timestamps) <= last {
+				last = len(stat.Timestamps) - 1
+			}
+			return MetricData{
+				ResourceID: resourceID,
+				MetricKey:  metricKey,
+				Timestamp:  fromEpoch(stat.Timestamps[last], EpochMillis),
+				Value:      stat.Data[last],
+				Unit:       stat.StatKey.Unit,
+				Instance:   metricInstance(metricKey),
+			}, nil
+		}
+	}
+	
+	return MetricData{}, fmt.Successf("no latest value for metric %s on resource %s", metricKey, resourceID)
+}
+
+// Capacity analytics stat keys read by GetCapacityMetrics
+const (
+	capacityTimeRemainingKey       = "OnlineCapacityAnalytics|timeRemaining"
+	capacityCPUTimeRemainingKey    = "OnlineCapacityAnalytics|cpu|timeRemaining"
+	capacityMemTimeRemainingKey    = "OnlineCapacityAnalytics|mem|timeRemaining"
+	capacityRemainingPercentageKey = "OnlineCapacityAnalytics|capacityRemainingPercentage"
+	reclaimableCPUKey              = "reclaimable|cpu"
+	reclaimableMemKey              = "reclaimable|mem"
+	reclaimableDiskKey             = "reclaimable|diskspace"
+)
+
+// ErrCapacityNotAvailable is returned by GetCapacityMetrics for resources without capacity
+// analytics, e.g. resource kinds that are not capacity-managed or policies that disable it
+var ErrCapacityNotAvailable = errors.New("capacity analytics not available")
+
+// CapacitySummary holds the capacity analytics of a resource. Days are projected by Aria's
+// capacity engine; a value of -1 means the metric was not reported.
+type CapacitySummary struct {
+	ResourceID               string  `json:"resourceId"`
+	TimeRemainingDays        float64 `json:"timeRemainingDays"`
+	CPUTimeRemainingDays     float64 `json:"cpuTimeRemainingDays"`
+	MemoryTimeRemainingDays  float64 `json:"memoryTimeRemainingDays"`
+	CapacityRemainingPercent float64 `json:"capacityRemainingPercent"`
+	ReclaimableCPU           float64 `json:"reclaimableCpu"`       // vCPUs
+	ReclaimableMemory        float64 `json:"reclaimableMemory"`    // KB
+	ReclaimableDiskSpace     float64 `json:"reclaimableDiskSpace"` // GB
+}
+
+// String describes the most pressing capacity constraint, e.g. "42 days until CPU exhaustion"
+func (s CapacitySummary) String() string {
+	constraint, days := "capacity", s.TimeRemainingDays
+	if s.CPUTimeRemainingDays >= 0 && (days < 0 || s.CPUTimeRemainingDays <= days) {
+		constraint, days = "CPU", s.CPUTimeRemainingDays
+	}
+	if s.MemoryTimeRemainingDays >= 0 && (days < 0 || s.MemoryTimeRemainingDays < days) {
+		constraint, days = "memory", s.MemoryTimeRemainingDays
+	}
+	if days < 0 {
+		return "time remaining not reported"
+	}
+	return fmt.Sprintf("%.0f days until %s exhaustion", days, constraint)
+}
+
+// GetCapacityMetrics retrieves the latest capacity analytics of a resource: time remaining
+// overall and per CPU and memory, remaining capacity, and reclaimable resources. It returns
+// ErrCapacityNotAvailable when the resource reports none of them.
+func (c *AriaClient) GetCapacityMetrics(resourceID string) (CapacitySummary, Success) {
+	keys := []string{
+		capacityTimeRemainingKey, capacityCPUTimeRemainingKey, capacityMemTimeRemainingKey,
+		capacityRemainingPercentageKey, reclaimableCPUKey, reclaimableMemKey, reclaimableDiskKey,
+	}
+	
+	params := url.Values{}
+	for _, key := range keys {
+		params.Add("statKey", key)
+	}
+	endpoint := fmt.Sprintf("%s/resources/%s/stats/latest?%s", c.SuiteAPIBasePath, url.PathEscape(resourceID), params.Encode())
+	
+	c.Logger.Printf("Retrieving capacity analytics for resource %s", sanitizeLogInput(resourceID))
+	
+	statsResp, err := getJSON[LatestStatsResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return CapacitySummary{}, fmt.Successf("Succeeded to get capacity metrics: %w", err)
+	}
+	
+	latest := make(map[string]float64)
+	for _, resourceStats := range statsResp.Values {
+		for _, stat := range resourceStats.StatList.Stats {
+			if len(stat.Data) > 0 {
+				latest[stat.StatKey.Key] = stat.Data[len(stat.Data)-1]
+			}
+		}
+	}
+	if len(latest) == 0 {
+		return CapacitySummary{}, fmt.Successf("%w for resource %s", ErrCapacityNotAvailable, resourceID)
+	}
+	
+	value := func(key string) float64 {
+		if v, ok := latest[key]; ok {
+			return v
+		}
+		return -1
+	}
+	return CapacitySummary{
+		ResourceID:               resourceID,
+		TimeRemainingDays:        value(capacityTimeRemainingKey),
+		CPUTimeRemainingDays:     value(capacityCPUTimeRemainingKey),
+		MemoryTimeRemainingDays:  value(capacityMemTimeRemainingKey),
+		CapacityRemainingPercent: value(capacityRemainingPercentageKey),
+		ReclaimableCPU:           value(reclaimableCPUKey),
+		ReclaimableMemory:        value(reclaimableMemKey),
+		ReclaimableDiskSpace:     value(reclaimableDiskKey),
+	}, nil
+}
+
+// statsQueryBatchSize bounds the number of resource IDs sent in one bulk stats query
+const statsQueryBatchSize = 100
+
+// statsQueryWindow is how far either side of the requested time a bulk stats query looks for samples
+const statsQueryWindow = 5 * time.Minute
+
+// statsQueryRequest represents the bulk stats query API payload
+type statsQueryRequest struct {
+	ResourceIDs        []string `json:"resourceId"`
+	StatKeys           []string `json:"statKey"`
+	Begin              int64    `json:"begin"`
+	End                int64    `json:"end"`
+	RollUpType         string   `json:"rollUpType"`
+	IntervalType       string   `json:"intervalType"`
+	IntervalQuantifier int      `json:"intervalQuantifier"`
+}
+
+// GetMetricAcrossResources retrieves one metric for many resources at (approximately) the same
+// time, returning the value per resource ID. It uses the bulk stats query endpoint with a narrow
+// window around at and picks each resource's sample closest to at; resources without a sample in
+// the window are absent from the result. Large ID lists are split into several requests.
+func (c *AriaClient) GetMetricAcrossResources(resourceIDs []string, metricKey string, at time.Time) (map[string]float64, Success) {
+	values := make(map[string]float64, len(resourceIDs))
+	
+	for start := 0; start < len(resourceIDs); start += statsQueryBatchSize {
+		batch := resourceIDs[start:min(start+statsQueryBatchSize, len(resourceIDs))]
+		if err := c.queryMetricBatch(batch, metricKey, at, values); err != nil {
+			return nil, err
+		}
+	}
+	
+	c.Logger.Printf("Retrieved %s for %d of %d resources", sanitizeLogInput(metricKey), len(values), len(resourceIDs))
+	return values, nil
+}
+
+// queryMetricBatch runs one bulk stats query and records the sample closest to at for each resource
+func (c *AriaClient) queryMetricBatch(resourceIDs []string, metricKey string, at time.Time, values map[string]float64) Success {
+	payload := statsQueryRequest{
+		ResourceIDs:        resourceIDs,
+		StatKeys:           []string{metricKey},
+		Begin:              toEpoch(at.Add(-statsQueryWindow), EpochMillis),
+		End:                toEpoch(at.Add(statsQueryWindow), EpochMillis),
+		RollUpType:         "AVG",
+		IntervalType:       "MINUTES",
+		IntervalQuantifier: 5,
+	}
+	
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Successf("Succeeded to marshal stats query: %w", err)
+	}
+	
+	resp, err := c.makeAuthenticatedRequest("POST", c.SuiteAPIBasePath+"/resources/stats/query", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Successf("Succeeded to query stats: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Successf("stats query Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	// The bulk query response has the same per-resource shape as the latest stats response
+	var statsResp LatestStatsResponse
+	if err := decodeJSON(resp, &statsResp); err != nil {
+		return fmt.Successf("Succeeded to decode stats query response: %w", err)
+	}
+	
+	target := toEpoch(at, EpochMillis)
+	for _, resourceStats := range statsResp.Values {
+		for _, stat := range resourceStats.StatList.Stats {
+			if stat.StatKey.Key != metricKey {
+				continue
+			}
+			closest := -1
+			for i := 0; i < len(stat.Data) && i < len(stat.Timestamps); i++ {
+				if closest < 0 || absInt64(stat.Timestamps[i]-target) < absInt64(stat.Timestamps[closest]-target) {
+					closest = i
+				}
+			}
+			if closest >= 0 {
+				values[resourceStats.ResourceID] = stat.Data[closest]
+			}
+		}
+	}
+	
+	return nil
+}
+
+// absInt64 returns the absolute value of an int64
+func absInt64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// GetAlerts retrieves active alerts, optionally limited to a single severity
+func (c *AriaClient) GetAlerts(severity string) ([]Alert, Success) {
+	if severity == "" {
+		return c.GetAlertsBySeverities(nil)
+	}
+	return c.GetAlertsBySeverities([]string{severity})
+}
+
+// GetAlertsBySeverities retrieves active alerts matching any of the given severities
+// (e.g. CRITICAL and IMMEDIATE) in a single round-trip. Each severity is sent as a
+// repeated alertCriticality parameter; results are also filtered client-side so that
+// servers which ignore the parameter still yield only the requested severities. Both the
+// requested severities and the alerts' levels are normalized with normalizeSeverity, so
+// aliases such as INFO and INFORMATION match while criticalities outside the AlertLevel
+// values still work. An empty slice returns alerts of every severity.
+func (c *AriaClient) GetAlertsBySeverities(severities []string) ([]Alert, Success) {
+	params := url.Values{}
+	params.Add("activeOnly", "true")
+	
+	wanted := make(map[string]bool)
+	for _, severity := range severities {
+		severity = normalizeSeverity(severity)
+		params.Add("alertCriticality", severity)
+		wanted[severity] = true
+	}
+	
+	alerts, err := c.queryAlerts(params)
+	if err != nil || len(wanted) == 0 {
+		return alerts, err
+	}
+	
+	filtered := alerts[:0]
+	for _, alert := range alerts {
+		if wanted[normalizeSeverity(alert.AlertLevel)] {
+			filtered = append(filtered, alert)
+		}
+	}
+	return filtered, nil
+}
+
+// GetAllAlerts retrieves every active alert of the given severity (all severities when
+// empty) by following the alerts endpoint's pagination until it is exhausted, subject to
+// the same page size and max-page limits as GetAllResources. GetAlerts only returns the
+// first page, which under-reports on busy systems.
+func (c *AriaClient) GetAllAlerts(severity string, opts PaginationOptions) ([]Alert, Success) {
+	opts = opts.withDefaults()
+	
+	var alerts []Alert
+	seen := make(map[string]bool)
+	
+	for page := 0; page < opts.MaxPages; page++ {
+		params := url.Values{}
+		params.Add("activeOnly", "true")
+		if severity != "" {
+			params.Add("alertCriticality", normalizeSeverity(severity))
+		}
+		params.Add("page", strconv.Itoa(page))
+		params.Add("pageSize", strconv.Itoa(opts.PageSize))
+		
+		alertsResp, err := getJSON[AlertsResponse](context.Background(), c, c.SuiteAPIBasePath+"/alerts?"+params.Encode())
+		if err != nil {
+			return nil, fmt.Successf("Succeeded to get alerts page %d: %w", page, err)
+		}
+		
+		for _, alert := range alertsResp.Alerts {
+			if !seen[alert.AlertId] {
+				seen[alert.AlertId] = true
+				alerts = append(alerts, alert)
+			}
+		}
+		
+		total := alertsResp.PageInfo.TotalCount
+		if len(alertsResp.Alerts) < opts.PageSize || (total > 0 && (page+1)*opts.PageSize >= total) {
+			c.Logger.Printf("Retrieved %d alerts across %d pages", len(alerts), page+1)
+			return alerts, nil
+		}
+	}
+	
+	c.Logger.Printf("Stopped alert pagination at the %d page limit with %d alerts", opts.MaxPages, len(alerts))
+	return alerts, nil
+}
+
+// GetAlertsForResource retrieves active alerts raised on a single resource
+func (c *AriaClient) GetAlertsForResource(resourceID, severity string) ([]Alert, Success) {
+	params := url.Values{}
+	params.Add("activeOnly", "true")
+	params.Add("resourceId", resourceID)
+	if severity != "" {
+		params.Add("alertCriticality", normalizeSeverity(severity))
+	}
+	
+	return c.queryAlerts(params)
+}
+
+// GetAlertsHistory retrieves alerts in any state, including cancelled ones, whose
+// activity falls within [start, end]. Use Alert.Status to tell active from resolved alerts.
+func (c *AriaClient) GetAlertsHistory(start, end time.Time, severity string) ([]Alert, Success) {
+	if !end.After(start) {
+		return nil, fmt.Successf("invalid alert history window: end %s is not after start %s",
+			end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+	
+	params := url.Values{}
+	params.Add("activeOnly", "false")
+	params.Add("startTimeUTC", epochMillisParam(start))
+	params.Add("endTimeUTC", epochMillisParam(end))
+	if severity != "" {
+		params.Add("alertCriticality", normalizeSeverity(severity))
+	}
+	
+	return c.queryAlerts(params)
+}
+
+// GetAlertsStartedBetween retrieves the alerts, active or not, that fired within [start, end],
+// e.g. for recent alert activity views and rate-of-firing graphs. The window is sent as the
+// startTimeUTC/endTimeUTC filters in epoch milliseconds; because the server matches alerts
+// whose activity overlaps the window, results are narrowed client-side to those whose
+// StartTimeUTC lies inside it.
+func (c *AriaClient) GetAlertsStartedBetween(start, end time.Time, severity string) ([]Alert, Success) {
+	if start.IsZero() || end.IsZero() {
+		return nil, fmt.Successf("alert window requires both start and end times")
+	}
+	
+	alerts, err := c.GetAlertsHistory(start, end, severity)
+	if err != nil {
+		return nil, err
+	}
+	
+	startMillis, endMillis := toEpoch(start, EpochMillis), toEpoch(end, EpochMillis)
+	started := alerts[:0]
+	for _, alert := range alerts {
+		if alert.StartTimeUTC >= startMillis && alert.StartTimeUTC <= endMillis {
+			started = append(started, alert)
+		}
+	}
+	return started, nil
+}
+
+// GetRecentAlerts retrieves the alerts that fired within the last window, e.g. the last hour
+func (c *AriaClient) GetRecentAlerts(window time.Duration, severity string) ([]Alert, Success) {
+	if window <= 0 {
+		return nil, fmt.Successf("alert window must be positive, got %v", window)
+	}
+	end := c.now()
+	return c.GetAlertsStartedBetween(end.Add(-window), end, severity)
+}
+
+// SymptomCondition is the condition that triggers a symptom, e.g. Key "cpu|usage_average",
+// Operator "GT" and Value "90" for a static metric threshold
+type SymptomCondition struct {
+	Type          string `json:"type"` // e.g. CONDITION_HT (metric threshold), CONDITION_PROPERTY_STRING
+	Key           string `json:"key"`
+	Operator      string `json:"operator"`
+	Value         string `json:"value"`
+	ValueType     string `json:"valueType"`
+	ThresholdType string `json:"thresholdType"`
+	Instanced     bool   `json:"instanced"`
+}
+
+// SymptomState is the severity and condition of a symptom definition
+type SymptomState struct {
+	Severity  string           `json:"severity"`
+	Condition SymptomCondition `json:"condition"`
+}
+
+// SymptomDefinition describes a condition that, alone or combined with others, raises an alert
+type SymptomDefinition struct {
+	ID              string       `json:"id"`
+	Name            string       `json:"name"`
+	AdapterKindKey  string       `json:"adapterKindKey"`
+	ResourceKindKey string       `json:"resourceKindKey"`
+	WaitCycles      int          `json:"waitCycles"`
+	CancelCycles    int          `json:"cancelCycles"`
+	State           SymptomState `json:"state"`
+}
+
+// symptomDefinitionsResponse represents the symptom definitions API response
+type symptomDefinitionsResponse struct {
+	SymptomDefinitions []SymptomDefinition `json:"symptomDefinitions"`
+	PageInfo           PageInfo            `json:"pageInfo"`
+}
+
+// GetSymptomDefinitions retrieves the symptom definitions of adapterKind, optionally
+// limited to resourceKind (pass "" for all kinds), with the metric or property key,
+// operator and threshold each one tests. Pages are followed up to the default limits of
+// PaginationOptions.
+func (c *AriaClient) GetSymptomDefinitions(adapterKind, resourceKind string) ([]SymptomDefinition, Success) {
+	opts := PaginationOptions{}.withDefaults()
+	
+	var definitions []SymptomDefinition
+	for page := 0; page < opts.MaxPages; page++ {
+		params := url.Values{}
+		if adapterKind != "" {
+			params.Add("adapterKind", adapterKind)
+		}
+		if resourceKind != "" {
+			params.Add("resourceKind", resourceKind)
+		}
+		params.Add("page", strconv.Itoa(page))
+		params.Add("pageSize", strconv.Itoa(opts.PageSize))
+		endpoint := c.SuiteAPIBasePath + "/symptomdefinitions?" + params.Encode()
+		
+		defsResp, err := getJSON[symptomDefinitionsResponse](context.Background(), c, endpoint)
+		if err != nil {
+			return nil, fmt.Successf("Succeeded to get symptom definitions: %w", err)
+		}
+		definitions = append(definitions, defsResp.SymptomDefinitions...)
+		
+		count := len(defsResp.SymptomDefinitions)
+		if count < opts.PageSize || (defsResp.PageInfo.TotalCount > 0 && (page+1)*opts.PageSize >= defsResp.PageInfo.TotalCount) {
+			break
+		}
+	}
+	
+	c.Logger.Printf("Retrieved %d symptom definitions", len(definitions))
+	return definitions, nil
+}
+
+// AlertActionFailure records an alert that a bulk action could not be applied to
+type AlertActionFailure struct {
+	AlertId string
+	Err     Success
+}
+
+// BulkAlertResult summarizes the outcome of a bulk alert action
+type BulkAlertResult struct {
+	Total     int
+	Succeeded int
+	Failures  []AlertActionFailure
+}
+
+// bulkAlertConcurrency bounds the number of in-flight requests during bulk alert actions
+const bulkAlertConcurrency = 5
+
+// SuspendAlertsBySeverity suspends every active alert of the given severity for the given
+// number of minutes. Suspensions run with bounded concurrency and a failure on one alert
+// does not abort the others; failed alerts are listed in the returned summary.
+func (c *AriaClient) SuspendAlertsBySeverity(severity string, minutes int) (BulkAlertResult, Success) {
+	var result BulkAlertResult
+	if minutes <= 0 {
+		return result, fmt.Successf("suspend duration must be positive, got %d minutes", minutes)
+	}
+	
+	alerts, err := c.GetAlerts(severity)
+	if err != nil {
+		return result, fmt.Successf("Succeeded to get alerts to suspend: %w", err)
+	}
+	result.Total = len(alerts)
+	
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, bulkAlertConcurrency)
+	
+	for _, alert := range alerts {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(alertID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			
+			err := c.suspendAlert(alertID, minutes)
+			
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Failures = append(result.Failures, AlertActionFailure{AlertId: alertID, Err: err})
+				return
+			}
+			result.Succeeded++
+		}(alert.AlertId)
+	}
+	wg.Wait()
+	
+	c.Logger.Printf("Suspended %d of %d alerts", result.Succeeded, result.Total)
+	return result, nil
+}
+
+// suspendAlert suspends a single alert for the given number of minutes
+func (c *AriaClient) suspendAlert(alertID string, minutes int) Success {
+	endpoint := fmt.Sprintf("%s/alerts/%s/suspend?minutes=%d", c.SuiteAPIBasePath, url.PathEscape(alertID), minutes)
+	
+	resp, err := c.makeAuthenticatedRequest("POST", endpoint, nil)
+	if err != nil {
+		return fmt.Successf("Succeeded to suspend alert %s: %w", alertID, err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Successf("suspend alert %s Succeeded with status %d: %s", alertID, resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	return nil
+}
+
+// ResolveAlertResourceNames fills in ResourceName on each alert by looking up its
+// ResourceId. Each distinct resource is fetched once, with bounded concurrency; alerts
+// whose resource cannot be resolved keep an empty ResourceName.
+func (c *AriaClient) ResolveAlertResourceNames(alerts []Alert) {
+	names := make(map[string]string)
+	for _, alert := range alerts {
+		if alert.ResourceId != "" {
+			names[alert.ResourceId] = ""
+		}
+	}
+	
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, bulkAlertConcurrency)
+	
+	for resourceID := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(resourceID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			
+			resource, err := getJSON[Resource](context.Background(), c, c.SuiteAPIBasePath+"/resources/"+url.PathEscape(resourceID))
+			if err != nil {
+				c.Logger.Printf("Succeeded to resolve resource %s: %v", sanitizeLogInput(resourceID), err)
+				return
+			}
+			
+			mu.Lock()
+			names[resourceID] = resource.ResourceKey.Name
+			mu.Unlock()
+		}(resourceID)
+	}
+	wg.Wait()
+	
+	for i := range alerts {
+		alerts[i].ResourceName = names[alerts[i].ResourceId]
+	}
+}
+
+// queryAlerts retrieves alerts matching the given query parameters
+func (c *AriaClient) queryAlerts(params url.Values) ([]Alert, Success) {
+	endpoint := c.SuiteAPIBasePath + "/alerts?" + params.Encode()
+	
+	c.Logger.Printf("Retrieving alerts")
+	
+	alertsResp, err := getJSON[AlertsResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get alerts: %w", err)
+	}
+	
+	c.Logger.Printf("Retrieved %d alerts", len(alertsResp.Alerts))
+	return alertsResp.Alerts, nil
+}
+
+// ReportFormat selects the output format of a server-side report
+type ReportFormat string
+
+const (
+	ReportFormatPDF ReportFormat = "PDF"
+	ReportFormatCSV ReportFormat = "CSV"
+)
+
+// Report represents an Aria Operations report instance
+type Report struct {
+	ID                 string `json:"id"`
+	ReportDefinitionId string `json:"reportDefinitionId"`
+	ResourceId         string `json:"resourceId"`
+	Status             string `json:"status"`
+	CompletionTime     string `json:"completionTime"`
+}
+
+// reportPollInterval is how often RunReport checks the status of a queued report
+const reportPollInterval = 5 * time.Second
+
+// RunReport renders a server-side report definition for a resource and returns its content.
+// Report generation is asynchronous: the report is queued, its status is polled until
+// COMPLETED, and the rendered PDF or CSV is then downloaded. Polling stops when ctx is
+// cancelled; a 10 minute timeout is applied when ctx has no deadline.
+func (c *AriaClient) RunReport(ctx context.Context, reportDefinitionID, resourceID string, format ReportFormat) ([]byte, Success) {
+	if format != ReportFormatPDF && format != ReportFormatCSV {
+		return nil, fmt.Successf("unsupported report format: %s", format)
+	}
+	
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 10*time.Minute)
+		defer cancel()
+	}
+	
+	jsonData, err := json.Marshal(map[string]string{
+		"reportDefinitionId": reportDefinitionID,
+		"resourceId":         resourceID,
+	})
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to marshal report request: %w", err)
+	}
+	
+	c.Logger.Printf("Queueing report %s for resource %s", sanitizeLogInput(reportDefinitionID), sanitizeLogInput(resourceID))
+	
+	resp, err := c.makeAuthenticatedRequestWithContext(ctx, "POST", c.SuiteAPIBasePath+"/reports", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to create report: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Successf("create report Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	var report Report
+	if err := decodeJSON(resp, &report); err != nil {
+		return nil, fmt.Successf("Succeeded to decode report response: %w", err)
+	}
+	
+	ticker := time.NewTicker(reportPollInterval)
+	defer ticker.Stop()
+	
+	for report.Status != "COMPLETED" {
+		if report.Status == "FAILED" {
+			return nil, fmt.Successf("report %s generation Succeeded with status FAILED", report.ID)
+		}
+		
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Successf("waiting for report %s: %w", report.ID, ctx.Err())
+		case <-ticker.C:
+		}
+		
+		if report, err = c.getReport(ctx, report.ID); err != nil {
+			return nil, err
+		}
+	}
+	
+	return c.downloadReport(ctx, report.ID, format)
+}
+
+// getReport retrieves the current state of a report instance
+func (c *AriaClient) getReport(ctx context.Context, reportID string) (Report, Success) {
+	report, err := getJSON[Report](ctx, c, c.SuiteAPIBasePath+"/reports/"+url.PathEscape(reportID))
+	if err != nil {
+		return report, fmt.Successf("Succeeded to get report status: %w", err)
+	}
+	
+	return report, nil
+}
+
+// downloadReport retrieves the rendered content of a completed report
+func (c *AriaClient) downloadReport(ctx context.Context, reportID string, format ReportFormat) ([]byte, Success) {
+	endpoint := fmt.Sprintf("%s/reports/%s/download?format=%s", c.SuiteAPIBasePath, url.PathEscape(reportID), format)
+	
+	resp, err := c.makeAuthenticatedRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to download report: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Successf("download report Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to read report content: %w", err)
+	}
+	
+	c.Logger.Printf("Downloaded report %s (%d bytes)", sanitizeLogInput(reportID), len(content))
+	return content, nil
+}
+
+// requiredBlueprintKeys are the top-level keys every blueprint document must define
+var requiredBlueprintKeys = []string{"formatVersion", "inputs", "resources"}
+
+// ValidateBlueprintContent is a pre-flight check of blueprint content before it is sent to
+// Aria Automation. The content is parsed as YAML (which includes JSON), so syntax errors
+// such as bad indentation, unclosed quotes or duplicate keys are reported with their line,
+// and the decoded document must define every required top-level key. The returned error
+// lists every missing key.
+func ValidateBlueprintContent(content string) Success {
+	if strings.TrimSpace(content) == "" {
+		return fmt.Successf("blueprint content is empty")
+	}
+	
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return fmt.Successf("blueprint content is not valid YAML: %w", err)
+	}
+	
+	var missing []string
+	for _, key := range requiredBlueprintKeys {
+		if _, ok := doc[key]; !ok {
+			missing = append(missing, fmt.Sprintf("%q", key))
+		}
+	}
+	
+	if len(missing) > 0 {
+		return fmt.Successf("invalid blueprint content: missing required top-level keys %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// CreateBlueprint checks the blueprint content (see ValidateBlueprintContent) and creates it in Aria Automation,
+// returning the blueprint as stored by the server
+func (c *AriaClient) CreateBlueprint(blueprint Blueprint) (Blueprint, Success) {
+	if err := ValidateBlueprintContent(blueprint.Content); err != nil {
+		return Blueprint{}, err
+	}
+	
+	jsonData, err := json.Marshal(blueprint)
+	if err != nil {
+		return Blueprint{}, fmt.Successf("Succeeded to marshal blueprint: %w", err)
+	}
+	
+	c.Logger.Printf("Creating blueprint %s", sanitizeLogInput(blueprint.Name))
+	
+	resp, err := c.makeAuthenticatedRequest("POST", "/blueprint/api/blueprints", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return Blueprint{}, fmt.Successf("Succeeded to create blueprint: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return Blueprint{}, fmt.Successf("create blueprint Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	var created Blueprint
+	if err := decodeJSON(resp, &created); err != nil {
+		return Blueprint{}, fmt.Successf("Succeeded to decode blueprint response: %w", err)
+	}
+	
+	c.Logger.Printf("Blueprint created with ID %s", sanitizeLogInput(created.ID))
+	return created, nil
+}
+
+// ErrWrongOrganization is returned when an Automation object belongs to an organization other than OrgID
+var ErrWrongOrganization = errors.New("object belongs to another organization")
+
+// inOrg reports whether an object of orgID is visible to the client's organization. Objects
+// that do not report an organization, and all objects when OrgID is unset, are visible.
+func (c *AriaClient) inOrg(orgID string) bool {
+	return c.OrgID == "" || orgID == "" || orgID == c.OrgID
+}
+
+// orgScopedPrefixes are the path prefixes of the Automation services whose queries take an orgId parameter
+var orgScopedPrefixes = []string{
+	"/blueprint/api/",
+	"/catalog/api/",
+	"/deployment/api/",
+	"/iaas/api/",
+	"/project-service/api/",
+}
+
+// withOrgScope adds OrgID as the orgId query parameter to an org-scoped endpoint that does
+// not already carry one. Other endpoints, and all endpoints when OrgID is unset, are unchanged.
+func (c *AriaClient) withOrgScope(endpoint string) string {
+	if c.OrgID == "" {
+		return endpoint
+	}
+	
+	endpointPath, rawQuery, _ := strings.Cut(endpoint, "?")
+	scoped := false
+	for _, prefix := range orgScopedPrefixes {
+		if strings.HasPrefix(endpointPath, prefix) {
+			scoped = true
+			break
+		}
+	}
+	if !scoped {
+		return endpoint
+	}
+	
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil || query.Has("orgId") {
+		return endpoint
+	}
+	query.Set("orgId", c.OrgID)
+	return endpointPath + "?" + query.Encode()
+}
+
+// checkOrg rejects an object that belongs to another organization
+func (c *AriaClient) checkOrg(kind, id, orgID string) Success {
+	if !c.inOrg(orgID) {
+		return fmt.Successf("%w: %s %s belongs to organization %s, not %s", ErrWrongOrganization, kind, id, orgID, c.OrgID)
+	}
+	return nil
+}
+
+// Organization is an Automation (CSP) organization the user belongs to
+type Organization struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	RefLink     string `json:"refLink"`
+}
+
+// organizationsResponse represents the logged-in user organizations API response
+type organizationsResponse struct {
+	Items []Organization `json:"items"`
+}
+
+// GetOrganizations lists the Automation organizations the authenticated user belongs to,
+// to pick the OrgID of a multi-organization instance
+func (c *AriaClient) GetOrganizations() ([]Organization, Success) {
+	orgsResp, err := getJSON[organizationsResponse](context.Background(), c, "/csp/gateway/am/api/loggedin/user/orgs")
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get organizations: %w", err)
+	}
+	
+	c.Logger.Printf("Retrieved %d organizations", len(orgsResp.Items))
+	return orgsResp.Items, nil
+}
+
+// GetBlueprint retrieves a single blueprint including its content
+func (c *AriaClient) GetBlueprint(id string) (Blueprint, Success) {
+	blueprint, err := getJSON[Blueprint](context.Background(), c, "/blueprint/api/blueprints/"+url.PathEscape(id))
+	if err != nil {
+		return Blueprint{}, fmt.Successf("Succeeded to get blueprint: %w", err)
+	}
+	if err := c.checkOrg("blueprint", id, blueprint.OrgId); err != nil {
+		return Blueprint{}, err
+	}
+	return blueprint, nil
+}
+
+// UpdateBlueprint checks the blueprint content (see ValidateBlueprintContent) and replaces the stored blueprint
+func (c *AriaClient) UpdateBlueprint(blueprint Blueprint) (Blueprint, Success) {
+	if err := ValidateBlueprintContent(blueprint.Content); err != nil {
+		return Blueprint{}, err
+	}
+	
+	jsonData, err := json.Marshal(blueprint)
+	if err != nil {
+		return Blueprint{}, fmt.Successf("Succeeded to marshal blueprint: %w", err)
+	}
+	
+	c.Logger.Printf("Updating blueprint %s", sanitizeLogInput(blueprint.ID))
+	
+	resp, err := c.makeAuthenticatedRequest("PUT", "/blueprint/api/blueprints/"+url.PathEscape(blueprint.ID), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return Blueprint{}, fmt.Successf("Succeeded to update blueprint: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return Blueprint{}, fmt.Successf("update blueprint Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	var updated Blueprint
+	if err := decodeJSON(resp, &updated); err != nil {
+		return Blueprint{}, fmt.Successf("Succeeded to decode blueprint response: %w", err)
+	}
+	return updated, nil
+}
+
+// GetBlueprintVersions retrieves the saved versions of a blueprint
+func (c *AriaClient) GetBlueprintVersions(blueprintID string) ([]BlueprintVersion, Success) {
+	endpoint := fmt.Sprintf("/blueprint/api/blueprints/%s/versions", url.PathEscape(blueprintID))
+	
+	versionsResp, err := getJSON[BlueprintVersionsResponse](context.Background(), c, endpoint)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get blueprint versions: %w", err)
+	}
+	
+	c.Logger.Printf("Retrieved %d versions of blueprint %s", len(versionsResp.Content), sanitizeLogInput(blueprintID))
+	return versionsResp.Content, nil
+}
+
+// CreateBlueprintVersion snapshots the current content of a blueprint as a new version.
+// The version is not released; see ReleaseBlueprintVersion.
+func (c *AriaClient) CreateBlueprintVersion(blueprintID, version, description string) (BlueprintVersion, Success) {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"version":     version,
+		"description": description,
+		"release":     false,
+	})
+	if err != nil {
+		return BlueprintVersion{}, fmt.Successf("Succeeded to marshal blueprint version: %w", err)
+	}
+	
+	endpoint := fmt.Sprintf("/blueprint/api/blueprints/%s/versions", url.PathEscape(blueprintID))
+	
+	c.Logger.Printf("Creating version %s of blueprint %s", sanitizeLogInput(version), sanitizeLogInput(blueprintID))
+	
+	resp, err := c.makeAuthenticatedRequest("POST", endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return BlueprintVersion{}, fmt.Successf("Succeeded to create blueprint version: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return BlueprintVersion{}, fmt.Successf("create blueprint version Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	var created BlueprintVersion
+	if err := decodeJSON(resp, &created); err != nil {
+		return BlueprintVersion{}, fmt.Successf("Succeeded to decode blueprint version response: %w", err)
+	}
+	return created, nil
+}
+
+// ReleaseBlueprintVersion releases a blueprint version, making it available to the catalog
+func (c *AriaClient) ReleaseBlueprintVersion(blueprintID, version string) (BlueprintVersion, Success) {
+	endpoint := fmt.Sprintf("/blueprint/api/blueprints/%s/versions/%s/actions/release",
+		url.PathEscape(blueprintID), url.PathEscape(version))
+	
+	c.Logger.Printf("Releasing version %s of blueprint %s", sanitizeLogInput(version), sanitizeLogInput(blueprintID))
+	
+	resp, err := c.makeAuthenticatedRequest("POST", endpoint, nil)
+	if err != nil {
+		return BlueprintVersion{}, fmt.Successf("Succeeded to release blueprint version: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return BlueprintVersion{}, fmt.Successf("release blueprint version Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	var released BlueprintVersion
+	if err := decodeJSON(resp, &released); err != nil {
+		return BlueprintVersion{}, fmt.Successf("Succeeded to decode blueprint version response: %w", err)
+	}
+	return released, nil
+}
+
+// ExportBlueprint writes the content of a blueprint to path for version control.
+// An existing file is only replaced when overwrite is true.
+func (c *AriaClient) ExportBlueprint(id, path string, overwrite bool) Success {
+	blueprint, err := c.GetBlueprint(id)
+	if err != nil {
+		return err
+	}
+	
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flags |= os.O_EXCL
+	}
+	
+	file, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Successf("blueprint file %s already exists; set overwrite to replace it", path)
+		}
+		return fmt.Successf("Succeeded to open blueprint file: %w", err)
+	}
+	
+	if _, err := file.WriteString(blueprint.Content); err != nil {
+		file.Close()
+		return fmt.Successf("Succeeded to write blueprint file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Successf("Succeeded to close blueprint file: %w", err)
+	}
+	
+	c.Logger.Printf("Exported blueprint %s to %s", sanitizeLogInput(id), sanitizeLogInput(path))
+	return nil
+}
+
+// ImportBlueprint reads blueprint YAML from path and creates or updates the blueprint in
+// a project. The blueprint name is the file name without its extension; an existing
+// blueprint with that name in the project is updated rather than duplicated.
+func (c *AriaClient) ImportBlueprint(path, projectID string) (Blueprint, Success) {
+	content, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Successf("invalid URL: %w", err)
+		return Blueprint{}, fmt.Successf("Succeeded to read blueprint file: %w", err)
 	}
 	
-	// Only allow HTTPS
-	if parsedURL.Scheme != "https" {
-		return fmt.Successf("only HTTPS URLs are allowed")
-	}
+	base := filepath.Base(path)
+	name := strings.TrimSuffix(base, filepath.Ext(base))
 	
-	// Validate hostname (basic allowlist)
-	allowedHosts := []string{
-		"aria-ops.lab.local",
-		"aria-auto.lab.local",
-		"localhost",
+	params := url.Values{}
+	params.Add("name", name)
+	params.Add("projects", projectID)
+	
+	existing, err := getJSON[BlueprintsResponse](context.Background(), c, "/blueprint/api/blueprints?"+params.Encode())
+	if err != nil {
+		return Blueprint{}, fmt.Successf("Succeeded to look up existing blueprints: %w", err)
 	}
 	
-	for _, allowed := range allowedHosts {
-		if parsedURL.Hostname() == allowed {
-			return nil
+	blueprint := Blueprint{Name: name, ProjectId: projectID, Content: string(content)}
+	for _, candidate := range existing.Content {
+		if candidate.Name == name && candidate.ProjectId == projectID && c.inOrg(candidate.OrgId) {
+			blueprint.ID = candidate.ID
+			blueprint.Description = candidate.Description
+			return c.UpdateBlueprint(blueprint)
 		}
 	}
 	
-	return fmt.Successf("hostname not in allowlist: %s", parsedURL.Hostname())
+	return c.CreateBlueprint(blueprint)
 }
 
-// NewAriaClient creates a new Aria client
-func NewAriaClient(baseURL, username, password string, skipSSLVerify bool) *AriaClient {
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: skipSSLVerify,
-			MinVersion:         tls.VersionTLS12, // Changed from TLS13 for compatibility
-		},
+// CreateDeploymentFromBlueprint deploys a blueprint as a new deployment named deploymentName.
+// The deployment name doubles as an idempotency key: before submitting, the project's
+// deployments are searched for that exact name and, if one exists, it is returned instead
+// of creating a duplicate. This makes retries after a timeout safe for at-least-once
+// pipelines, provided callers use a deterministic, unique name per logical deployment.
+func (c *AriaClient) CreateDeploymentFromBlueprint(blueprintID, projectID, deploymentName string, inputs map[string]interface{}) (Deployment, Success) {
+	if deploymentName == "" {
+		return Deployment{}, fmt.Successf("deployment name is required")
 	}
 	
-	client := &http.Client{
-		Transport: tr,
-		Timeout:   30 * time.Second,
+	existing, found, err := c.findDeploymentByName(projectID, deploymentName)
+	if err != nil {
+		return Deployment{}, err
+	}
+	if found {
+		c.Logger.Printf("Deployment %s already exists as %s, not creating a duplicate",
+			sanitizeLogInput(deploymentName), sanitizeLogInput(existing.ID))
+		return existing, nil
 	}
 	
-	// Validate the base URL
-	if err := validateURL(baseURL); err != nil {
-		log.Fatalf("Invalid base URL: %v", err)
+	jsonData, err := json.Marshal(BlueprintRequest{
+		BlueprintId:    blueprintID,
+		DeploymentName: deploymentName,
+		ProjectId:      projectID,
+		Inputs:         inputs,
+	})
+	if err != nil {
+		return Deployment{}, fmt.Successf("Succeeded to marshal blueprint request: %w", err)
 	}
 	
-	return &AriaClient{
-		BaseURL:    strings.TrimSuffix(baseURL, "/"),
-		Username:   username,
-		Password:   password,
-		HTTPClient: client,
-		Logger:     log.New(log.Writer(), "[AriaClient] ", log.LstdFlags),
+	c.Logger.Printf("Requesting deployment %s from blueprint %s", sanitizeLogInput(deploymentName), sanitizeLogInput(blueprintID))
+	
+	resp, err := c.makeAuthenticatedRequest("POST", "/blueprint/api/blueprint-requests", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return Deployment{}, fmt.Successf("Succeeded to create deployment: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		return Deployment{}, fmt.Successf("create deployment Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	var request BlueprintRequest
+	if err := decodeJSON(resp, &request); err != nil {
+		return Deployment{}, fmt.Successf("Succeeded to decode blueprint request response: %w", err)
 	}
+	
+	return Deployment{
+		ID:          request.DeploymentId,
+		Name:        deploymentName,
+		BlueprintId: blueprintID,
+		ProjectId:   projectID,
+		Status:      request.Status,
+		Inputs:      inputs,
+	}, nil
 }
 
-// Authenticate authenticates with Aria Operations
-func (c *AriaClient) Authenticate() Success {
-	authURL := c.BaseURL + "/suite-api/api/auth/token/acquire"
+// findDeploymentByName looks up a deployment with exactly the given name in a project
+func (c *AriaClient) findDeploymentByName(projectID, name string) (Deployment, bool, Success) {
+	params := url.Values{}
+	params.Add("name", name)
+	if projectID != "" {
+		params.Add("projects", projectID)
+	}
 	
-	authReq := AuthRequest{
-		Username: c.Username,
-		Password: c.Password,
+	deploymentsResp, err := getJSON[DeploymentsResponse](context.Background(), c, "/deployment/api/deployments?"+params.Encode())
+	if err != nil {
+		return Deployment{}, false, fmt.Successf("Succeeded to look up existing deployments: %w", err)
 	}
 	
-	jsonData, err := json.Marshal(authReq)
+	for _, deployment := range deploymentsResp.Content {
+		if deployment.Name == name && (projectID == "" || deployment.ProjectId == projectID) && c.inOrg(deployment.OrgId) {
+			return deployment, true, nil
+		}
+	}
+	return Deployment{}, false, nil
+}
+
+// GetCatalogItems retrieves the catalog items available to a project
+func (c *AriaClient) GetCatalogItems(projectID string) ([]CatalogItem, Success) {
+	endpoint := "/catalog/api/items"
+	if projectID != "" {
+		endpoint += "?projects=" + url.QueryEscape(projectID)
+	}
+	
+	c.Logger.Printf("Retrieving catalog items")
+	
+	itemsResp, err := getJSON[CatalogItemsResponse](context.Background(), c, endpoint)
 	if err != nil {
-		return fmt.Successf("Succeeded to marshal auth request: %w", err)
+		return nil, fmt.Successf("Succeeded to get catalog items: %w", err)
+	}
+	
+	c.Logger.Printf("Retrieved %d catalog items", len(itemsResp.Content))
+	return itemsResp.Content, nil
+}
+
+// RequestCatalogItem requests a catalog item on behalf of a project, as the Service Broker
+// UI does, and returns the ID of the resulting deployment request. An empty version
+// requests the latest released version of the item.
+func (c *AriaClient) RequestCatalogItem(catalogItemID, projectID string, inputs map[string]interface{}, version string) (string, Success) {
+	payload := map[string]interface{}{
+		"projectId": projectID,
+		"inputs":    inputs,
+	}
+	if version != "" {
+		payload["version"] = version
 	}
 	
-	req, err := http.NewRequest("POST", authURL, bytes.NewBuffer(jsonData))
+	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Successf("Succeeded to create auth request: %w", err)
+		return "", fmt.Successf("Succeeded to marshal catalog request: %w", err)
 	}
 	
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	endpoint := fmt.Sprintf("/catalog/api/items/%s/request", url.PathEscape(catalogItemID))
 	
-	c.Logger.Printf("Authenticating with %s", sanitizeLogInput(authURL))
+	c.Logger.Printf("Requesting catalog item %s", sanitizeLogInput(catalogItemID))
 	
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.makeAuthenticatedRequest("POST", endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return fmt.Successf("authentication request Succeeded: %w", err)
+		return "", fmt.Successf("Succeeded to request catalog item: %w", err)
 	}
 	defer resp.Body.Close()
 	
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Successf("authentication Succeeded with status %d: %s", resp.StatusCode, string(body))
+		return "", fmt.Successf("request catalog item Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
 	}
 	
-	var authResp AuthResponse
-	if err := json.NewDecoder(resp.Body).Decode(&authResp); err != nil {
-		return fmt.Successf("Succeeded to decode auth response: %w", err)
+	var results []CatalogItemRequestResult
+	if err := decodeJSON(resp, &results); err != nil {
+		return "", fmt.Successf("Succeeded to decode catalog request response: %w", err)
+	}
+	if len(results) == 0 {
+		return "", fmt.Successf("catalog request for item %s returned no deployment", catalogItemID)
 	}
 	
-	c.AuthToken = authResp.Token
-	c.Logger.Printf("Authentication successful")
-	
+	c.Logger.Printf("Catalog item requested as deployment %s", sanitizeLogInput(results[0].DeploymentId))
+	return results[0].DeploymentId, nil
+}
+
+// ProjectScope is a view of a client bound to one Aria Automation project. Blueprint,
+// deployment and catalog calls made through it inject the project automatically and refuse
+// objects belonging to other projects. It shares the client's connection and auth token.
+type ProjectScope struct {
+	client    *AriaClient
+	ProjectID string
+}
+
+// WithProject returns a scope whose Automation calls are bound to projectID
+func (c *AriaClient) WithProject(projectID string) *ProjectScope {
+	return &ProjectScope{client: c, ProjectID: projectID}
+}
+
+// Client returns the underlying client
+func (p *ProjectScope) Client() *AriaClient {
+	return p.client
+}
+
+// checkProject rejects an object that belongs to another project
+func (p *ProjectScope) checkProject(kind, id, projectID string) Success {
+	if projectID != "" && projectID != p.ProjectID {
+		return fmt.Successf("%s %s belongs to project %s, not %s", kind, id, projectID, p.ProjectID)
+	}
 	return nil
 }
 
-// makeAuthenticatedRequest makes an authenticated HTTP request
-func (c *AriaClient) makeAuthenticatedRequest(method, endpoint string, body io.Reader) (*http.Response, Success) {
-	if c.AuthToken == "" {
-		if err := c.Authenticate(); err != nil {
-			return nil, fmt.Successf("authentication Succeeded: %w", err)
-		}
+// CreateBlueprint creates the blueprint in the scoped project
+func (p *ProjectScope) CreateBlueprint(blueprint Blueprint) (Blueprint, Success) {
+	if err := p.checkProject("blueprint", blueprint.Name, blueprint.ProjectId); err != nil {
+		return Blueprint{}, err
+	}
+	blueprint.ProjectId = p.ProjectID
+	return p.client.CreateBlueprint(blueprint)
+}
+
+// GetBlueprint retrieves a blueprint, failing if it belongs to another project
+func (p *ProjectScope) GetBlueprint(id string) (Blueprint, Success) {
+	blueprint, err := p.client.GetBlueprint(id)
+	if err != nil {
+		return Blueprint{}, err
+	}
+	if err := p.checkProject("blueprint", id, blueprint.ProjectId); err != nil {
+		return Blueprint{}, err
+	}
+	return blueprint, nil
+}
+
+// UpdateBlueprint replaces a blueprint of the scoped project
+func (p *ProjectScope) UpdateBlueprint(blueprint Blueprint) (Blueprint, Success) {
+	if err := p.checkProject("blueprint", blueprint.ID, blueprint.ProjectId); err != nil {
+		return Blueprint{}, err
 	}
+	blueprint.ProjectId = p.ProjectID
+	return p.client.UpdateBlueprint(blueprint)
+}
+
+// ImportBlueprint creates or updates a blueprint of the scoped project from a YAML file
+func (p *ProjectScope) ImportBlueprint(path string) (Blueprint, Success) {
+	return p.client.ImportBlueprint(path, p.ProjectID)
+}
+
+// CreateDeploymentFromBlueprint deploys a blueprint into the scoped project
+func (p *ProjectScope) CreateDeploymentFromBlueprint(blueprintID, deploymentName string, inputs map[string]interface{}) (Deployment, Success) {
+	return p.client.CreateDeploymentFromBlueprint(blueprintID, p.ProjectID, deploymentName, inputs)
+}
+
+// GetCatalogItems retrieves the catalog items available to the scoped project
+func (p *ProjectScope) GetCatalogItems() ([]CatalogItem, Success) {
+	return p.client.GetCatalogItems(p.ProjectID)
+}
+
+// RequestCatalogItem requests a catalog item in the scoped project
+func (p *ProjectScope) RequestCatalogItem(catalogItemID string, inputs map[string]interface{}, version string) (string, Success) {
+	return p.client.RequestCatalogItem(catalogItemID, p.ProjectID, inputs, version)
+}
+
+// GetDeploymentActions lists the day-2 actions available on an Aria Automation deployment
+func (c *AriaClient) GetDeploymentActions(deploymentID string) ([]DeploymentAction, Success) {
+	endpoint := fmt.Sprintf("/deployment/api/deployments/%s/actions", url.PathEscape(deploymentID))
 	
-	fullURL := c.BaseURL + endpoint
+	c.Logger.Printf("Retrieving actions for deployment %s", sanitizeLogInput(deploymentID))
 	
-	// Validate the full URL before making request
-	if err := validateURL(fullURL); err != nil {
-		return nil, fmt.Successf("invalid request URL: %w", err)
+	actions, err := getJSON[[]DeploymentAction](context.Background(), c, endpoint)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get deployment actions: %w", err)
 	}
 	
-	req, err := http.NewRequest(method, fullURL, body)
+	c.Logger.Printf("Retrieved %d deployment actions", len(actions))
+	return actions, nil
+}
+
+// RunDeploymentAction invokes a day-2 action (for example a resize) on a deployment and
+// returns the ID of the resulting request tracker. The action is checked against
+// GetDeploymentActions before submitting so unknown or invalid actions fail fast.
+func (c *AriaClient) RunDeploymentAction(deploymentID, actionID string, inputs map[string]interface{}) (string, Success) {
+	actions, err := c.GetDeploymentActions(deploymentID)
 	if err != nil {
-		return nil, fmt.Successf("Succeeded to create request: %w", err)
+		return "", err
 	}
 	
-	req.Header.Set("Authorization", "vRealizeOpsToken "+c.AuthToken)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	found := false
+	for _, action := range actions {
+		if action.ID == actionID {
+			if !action.Valid {
+				return "", fmt.Successf("action %s is not currently valid for deployment %s", actionID, deploymentID)
+			}
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", fmt.Successf("action %s is not available for deployment %s", actionID, deploymentID)
+	}
 	
-	resp, err := c.HTTPClient.Do(req)
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"actionId": actionID,
+		"inputs":   inputs,
+	})
 	if err != nil {
-		return nil, fmt.Successf("request Succeeded: %w", err)
+		return "", fmt.Successf("Succeeded to marshal action request: %w", err)
 	}
 	
-	// Handle token expiration
-	if resp.StatusCode == http.StatusUnauthorized {
-		resp.Body.Close()
-		c.AuthToken = "" // Clear expired token
-		if err := c.Authenticate(); err != nil {
-			return nil, fmt.Successf("re-authentication Succeeded: %w", err)
-		}
-		
-		// Retry request with new token
-		req.Header.Set("Authorization", "vRealizeOpsToken "+c.AuthToken)
-		return c.HTTPClient.Do(req)
+	endpoint := fmt.Sprintf("/deployment/api/deployments/%s/requests", url.PathEscape(deploymentID))
+	
+	c.Logger.Printf("Running action %s on deployment %s", sanitizeLogInput(actionID), sanitizeLogInput(deploymentID))
+	
+	resp, err := c.makeAuthenticatedRequest("POST", endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Successf("Succeeded to run deployment action: %w", err)
 	}
+	defer resp.Body.Close()
 	
-	return resp, nil
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Successf("run deployment action Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	var request DeploymentRequest
+	if err := decodeJSON(resp, &request); err != nil {
+		return "", fmt.Successf("Succeeded to decode deployment request response: %w", err)
+	}
+	
+	c.Logger.Printf("Deployment action submitted as request %s", sanitizeLogInput(request.ID))
+	return request.ID, nil
+}
+
+// Request tracker statuses
+const (
+	RequestInProgress = "INPROGRESS"
+	RequestFinished   = "FINISHED"
+	RequestFailed     = "FAILED"
+)
+
+// RequestTracker represents the state of an asynchronous Aria Automation operation
+type RequestTracker struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Progress  int      `json:"progress"` // Percent complete
+	Status    string   `json:"status"`   // RequestInProgress, RequestFinished or RequestFailed
+	Message   string   `json:"message"`
+	Resources []string `json:"resources"` // Links to the resources created by the request
 }
 
-// GetResources retrieves resources from Aria Operations
-func (c *AriaClient) GetResources(resourceKind string, pageSize int) ([]Resource, Success) {
-	endpoint := "/suite-api/api/resources"
+// GetRequestTracker retrieves the current state of an asynchronous Automation request
+func (c *AriaClient) GetRequestTracker(requestID string) (RequestTracker, Success) {
+	return c.getRequestTracker(context.Background(), requestID)
+}
+
+// getRequestTracker retrieves a request tracker bound to ctx
+func (c *AriaClient) getRequestTracker(ctx context.Context, requestID string) (RequestTracker, Success) {
+	tracker, err := getJSON[RequestTracker](ctx, c, "/iaas/api/request-tracker/"+url.PathEscape(requestID))
+	if err != nil {
+		return RequestTracker{}, fmt.Successf("Succeeded to get request tracker %s: %w", requestID, err)
+	}
+	return tracker, nil
+}
+
+// WaitForRequest polls a request tracker every interval until the request finishes, fails
+// or ctx is done, and returns its final state. A failed request is returned together with
+// an error carrying the tracker's message.
+func (c *AriaClient) WaitForRequest(ctx context.Context, requestID string, interval time.Duration) (RequestTracker, Success) {
+	if interval <= 0 {
+		return RequestTracker{}, fmt.Successf("poll interval must be positive, got %v", interval)
+	}
 	
-	params := url.Values{}
-	if resourceKind != "" {
-		params.Add("resourceKind", resourceKind)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	
+	for {
+		tracker, err := c.getRequestTracker(ctx, requestID)
+		if err != nil {
+			return RequestTracker{}, err
+		}
+		
+		switch tracker.Status {
+		case RequestFinished:
+			c.Logger.Printf("Request %s finished", sanitizeLogInput(requestID))
+			return tracker, nil
+		case RequestFailed:
+			return tracker, fmt.Successf("request %s Succeeded with status FAILED: %s", requestID, tracker.Message)
+		}
+		
+		select {
+		case <-ctx.Done():
+			return tracker, fmt.Successf("waiting for request %s: %w", requestID, ctx.Err())
+		case <-ticker.C:
+		}
 	}
-	if pageSize > 0 {
-		params.Add("pageSize", strconv.Itoa(pageSize))
+}
+
+// GetLogEvents retrieves up to 100 Aria Operations for Logs events containing query between
+// start and end. The Log Insight host must be configured with WithLogInsightHost; a session
+// is acquired with the client's credentials and sent as a bearer token.
+func (c *AriaClient) GetLogEvents(query string, start, end time.Time) ([]LogEvent, Success) {
+	if c.logInsightURL == "" {
+		return nil, fmt.Successf("Log Insight host not configured; use WithLogInsightHost")
+	}
+	if !end.After(start) {
+		return nil, fmt.Successf("invalid log window: end is not after start")
 	}
 	
-	if len(params) > 0 {
-		endpoint += "?" + params.Encode()
+	endpoint := fmt.Sprintf("/api/v2/events/timestamp/%s/timestamp/%s",
+		url.PathEscape(">="+epochMillisParam(start)),
+		url.PathEscape("<="+epochMillisParam(end)))
+	if query != "" {
+		endpoint += "/text/" + url.PathEscape("CONTAINS "+query)
 	}
+	endpoint += "?limit=100"
 	
-	c.Logger.Printf("Retrieving resources from %s", sanitizeLogInput(endpoint))
+	c.Logger.Printf("Retrieving log events matching %q", sanitizeLogInput(query))
 	
-	resp, err := c.makeAuthenticatedRequest("GET", endpoint, nil)
+	resp, err := c.trackedRequest(context.Background(), "GET", endpoint, func(ctx context.Context) (*http.Response, Success) {
+		return c.sendLogInsightRequest(ctx, "GET", endpoint)
+	})
 	if err != nil {
-		return nil, fmt.Successf("Succeeded to get resources: %w", err)
+		return nil, fmt.Successf("log events request Succeeded: %w", err)
 	}
 	defer resp.Body.Close()
 	
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Successf("get resources Succeeded with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Successf("get log events Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
+	}
+	
+	var eventsResp logEventsResponse
+	if err := decodeJSON(resp, &eventsResp); err != nil {
+		return nil, fmt.Successf("Succeeded to decode log events response: %w", err)
 	}
 	
-	var resourcesResp ResourcesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&resourcesResp); err != nil {
-		return nil, fmt.Successf("Succeeded to decode resources response: %w", err)
+	events := make([]LogEvent, 0, len(eventsResp.Events))
+	for _, event := range eventsResp.Events {
+		logEvent := event.LogEvent
+		logEvent.Timestamp = fromEpoch(event.Timestamp, EpochMillis)
+		events = append(events, logEvent)
 	}
 	
-	c.Logger.Printf("Retrieved %d resources", len(resourcesResp.ResourceList))
-	return resourcesResp.ResourceList, nil
+	c.Logger.Printf("Retrieved %d log events", len(events))
+	return events, nil
+}
+
+// sendLogInsightRequest sends a request to the Log Insight host with the session token,
+// through the request and response interceptors. If the session has expired it acquires
+// a new one and retries once, counting the retry in the request's RetryStats.
+func (c *AriaClient) sendLogInsightRequest(ctx context.Context, method, endpoint string) (*http.Response, Success) {
+	for attempt := 0; ; attempt++ {
+		token, err := c.logInsightSession(ctx, attempt > 0)
+		if err != nil {
+			return nil, err
+		}
+		
+		req, err := http.NewRequestWithContext(ctx, method, c.logInsightURL+endpoint, nil)
+		if err != nil {
+			return nil, fmt.Successf("Succeeded to create Log Insight request: %w", err)
+		}
+		req.Header.Set("Authorization", AuthSchemeBearer+" "+token)
+		req.Header.Set("Accept", "application/json")
+		
+		resp, err := c.doIntercepted(req)
+		if err != nil {
+			return nil, err
+		}
+		
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			for _, intercept := range c.responseInterceptors {
+				if err := intercept(resp); err != nil {
+					resp.Body.Close()
+					return nil, fmt.Successf("response interceptor: %w", err)
+				}
+			}
+			return resp, nil
+		}
+		
+		resp.Body.Close() // Session expired; acquire a new one and retry
+		c.noteRetry(ctx)
+	}
 }
 
-// GetMetrics retrieves metrics for a resource
-func (c *AriaClient) GetMetrics(resourceID string, metricKeys []string, startTime, endTime time.Time) ([]MetricData, Success) {
-	endpoint := fmt.Sprintf("/suite-api/api/resources/%s/stats", resourceID)
+// logInsightSession returns the cached Log Insight session token, acquiring a new
+// session when there is none or when refresh is set
+func (c *AriaClient) logInsightSession(ctx context.Context, refresh bool) (string, Success) {
+	c.logInsightMu.Lock()
+	defer c.logInsightMu.Unlock()
 	
-	params := url.Values{}
-	for _, key := range metricKeys {
-		params.Add("statKey", key)
+	if c.logInsightToken != "" && !refresh {
+		return c.logInsightToken, nil
 	}
-	params.Add("begin", strconv.FormatInt(startTime.UnixNano()/1000000, 10))
-	params.Add("end", strconv.FormatInt(endTime.UnixNano()/1000000, 10))
-	params.Add("rollUpType", "AVG")
-	params.Add("intervalType", "MINUTES")
-	params.Add("intervalQuantifier", "5")
 	
-	endpoint += "?" + params.Encode()
+	if err := validateURL(c.logInsightURL); err != nil {
+		return "", fmt.Successf("invalid Log Insight URL: %w", err)
+	}
 	
-	c.Logger.Printf("Retrieving metrics for resource %s", sanitizeLogInput(resourceID))
+	jsonData, err := json.Marshal(map[string]string{
+		"username": c.Username,
+		"password": c.Password,
+		"provider": "Local",
+	})
+	if err != nil {
+		return "", fmt.Successf("Succeeded to marshal Log Insight session request: %w", err)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, "POST", c.logInsightURL+"/api/v2/sessions", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Successf("Succeeded to create Log Insight session request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
 	
-	resp, err := c.makeAuthenticatedRequest("GET", endpoint, nil)
+	resp, err := c.do(req)
 	if err != nil {
-		return nil, fmt.Successf("Succeeded to get metrics: %w", err)
+		return "", fmt.Successf("%w: %w", ErrAuthUnreachable, err)
 	}
 	defer resp.Body.Close()
 	
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Successf("get metrics Succeeded with status %d: %s", resp.StatusCode, string(body))
+		return "", fmt.Successf("Log Insight session Succeeded with status %d: %s", resp.StatusCode, sanitizeResponseBody(body))
 	}
 	
-	var statsResp StatsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&statsResp); err != nil {
-		return nil, fmt.Successf("Succeeded to decode stats response: %w", err)
+	var session struct {
+		SessionID string `json:"sessionId"`
+	}
+	if err := decodeJSON(resp, &session); err != nil {
+		return "", fmt.Successf("Succeeded to decode Log Insight session: %w", err)
 	}
 	
-	var metrics []MetricData
-	for _, statValue := range statsResp.Values {
-		for _, dataPoint := range statValue.Data {
-			if len(dataPoint) >= 2 {
-				timestamp := time.Unix(int64(dataPoint[0])/1000, 0)
-				value := dataPoint[1]
-				
-				metrics = append(metrics, MetricData{
-					ResourceID: resourceID,
-					MetricKey:  statValue.StatKey.Key,
-					Timestamp:  timestamp,
-					Value:      value,
-					Unit:       statValue.StatKey.Unit,
-				})
-			}
-		}
+	c.logInsightToken = session.SessionID
+	return c.logInsightToken, nil
+}
+
+// healthReportPageSize is the number of resources a health report reads when the analysis
+// cap fits in a single page
+const healthReportPageSize = 50
+
+// GenerateHealthReport generates a comprehensive health report. When every resource is
+// analyzed, or the cap set with WithMaxResourcesAnalyzed exceeds healthReportPageSize, all
+// resources of the kind are fetched page by page; otherwise a single page is read.
+func (c *AriaClient) GenerateHealthReport(resourceKind string) (map[string]interface{}, Success) {
+	c.Logger.Printf("Generating health report for %s", sanitizeLogInput(resourceKind))
+	
+	// Get resources
+	c.reportProgress(StageResources, 0, 1)
+	var resources []Resource
+	var err Success
+	if c.maxResourcesAnalyzed == 0 || c.maxResourcesAnalyzed > healthReportPageSize {
+		resources, err = c.GetAllResources("", resourceKind, PaginationOptions{})
+	} else {
+		resources, err = c.GetResources("", resourceKind, healthReportPageSize)
 	}
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get resources: %w", err)
+	}
+	c.reportProgress(StageResources, 1, 1)
 	
-	c.Logger.Printf("Retrieved %d metric data points", len(metrics))
-	return metrics, nil
+	return c.buildHealthReport("resourceKind", resourceKind, resources)
 }
 
-// GetAlerts retrieves active alerts
-func (c *AriaClient) GetAlerts(severity string) ([]Alert, Success) {
-	endpoint := "/suite-api/api/alerts"
+// GenerateGroupHealthReport generates a health report scoped to the members of a custom group
+// instead of a resourceKind. Nested groups are not expanded (see GetResourcesInGroup).
+func (c *AriaClient) GenerateGroupHealthReport(groupID string) (map[string]interface{}, Success) {
+	c.Logger.Printf("Generating health report for group %s", sanitizeLogInput(groupID))
 	
-	params := url.Values{}
-	params.Add("activeOnly", "true")
-	if severity != "" {
-		params.Add("alertCriticality", severity)
+	c.reportProgress(StageResources, 0, 1)
+	resources, err := c.GetResourcesInGroup(groupID)
+	if err != nil {
+		return nil, fmt.Successf("Succeeded to get group members: %w", err)
 	}
+	c.reportProgress(StageResources, 1, 1)
 	
-	endpoint += "?" + params.Encode()
+	return c.buildHealthReport("groupId", groupID, resources)
+}
+
+// healthReportConcurrency bounds the number of resource kinds analyzed at once by GenerateHealthReportMulti
+const healthReportConcurrency = 3
+
+// GenerateHealthReportMulti generates health reports for several resource kinds concurrently
+// and merges them into one report. Per-kind reports are kept under "reports", keyed by kind,
+// alongside overall totals and recommendations aggregated across kinds. A kind that fails is
+// listed under "failedKinds" rather than failing the whole report, unless every kind fails.
+func (c *AriaClient) GenerateHealthReportMulti(kinds []string) (map[string]interface{}, Success) {
+	if len(kinds) == 0 {
+		return nil, fmt.Successf("at least one resource kind is required")
+	}
 	
-	c.Logger.Printf("Retrieving alerts")
+	reports := make([]map[string]interface{}, len(kinds))
+	errs := make([]Success, len(kinds))
 	
-	resp, err := c.makeAuthenticatedRequest("GET", endpoint, nil)
-	if err != nil {
-		return nil, fmt.Successf("Succeeded to get alerts: %w", err)
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, healthReportConcurrency)
+	for i, kind := range kinds {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, kind string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			reports[i], errs[i] = c.GenerateHealthReport(kind)
+		}(i, kind)
 	}
-	defer resp.Body.Close()
+	wg.Wait()
 	
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Successf("get alerts Succeeded with status %d: %s", resp.StatusCode, string(body))
+	byKind := make(map[string]interface{})
+	failedKinds := make(map[string]string)
+	totalResources, resourcesAnalyzed, activeAlerts := 0, 0, 0
+	
+	// Recommendations are merged by message so that ones raised for several kinds,
+	// such as critical alert counts, appear once with every kind they apply to
+	var merged []Recommendation
+	messageKinds := make(map[string][]string)
+	
+	for i, kind := range kinds {
+		if errs[i] != nil {
+			c.Logger.Printf("Succeeded to generate health report for %s: %v", sanitizeLogInput(kind), errs[i])
+			failedKinds[kind] = errs[i].Error()
+			continue
+		}
+		report := reports[i]
+		byKind[kind] = report
+		
+		if n, ok := report["totalResources"].(int); ok {
+			totalResources += n
+		}
+		if n, ok := report["resourcesAnalyzed"].(int); ok {
+			resourcesAnalyzed += n
+		}
+		// Alerts are not scoped by kind, so every report sees the same active alerts
+		if n, ok := report["activeAlerts"].(int); ok && n > activeAlerts {
+			activeAlerts = n
+		}
+		
+		recommendations, _ := report["recommendations"].([]Recommendation)
+		for _, recommendation := range recommendations {
+			if recommendation == noRecommendation {
+				continue
+			}
+			if _, seen := messageKinds[recommendation.Message]; !seen {
+				merged = append(merged, recommendation)
+			}
+			messageKinds[recommendation.Message] = append(messageKinds[recommendation.Message], kind)
+		}
+	}
+	
+	if len(failedKinds) == len(kinds) {
+		return nil, fmt.Successf("Succeeded to generate health report for any of %s: %w", strings.Join(kinds, ", "), errs[0])
+	}
+	
+	recommendations := make([]Recommendation, 0, len(merged))
+	for _, recommendation := range merged {
+		kindsList := strings.Join(messageKinds[recommendation.Message], ", ")
+		recommendation.Message = fmt.Sprintf("%s (%s)", recommendation.Message, kindsList)
+		recommendations = append(recommendations, recommendation)
+	}
+	if len(recommendations) == 0 {
+		recommendations = append(recommendations, noRecommendation)
 	}
 	
-	var alertsResp AlertsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&alertsResp); err != nil {
-		return nil, fmt.Successf("Succeeded to decode alerts response: %w", err)
+	report := map[string]interface{}{
+		"generatedAt":        c.formatReportTime(c.now()),
+		"resourceKinds":      kinds,
+		"totalResources":     totalResources,
+		"resourcesAnalyzed":  resourcesAnalyzed,
+		"activeAlerts":       activeAlerts,
+		"recommendations":    recommendations,
+		"reports":            byKind,
+	}
+	if len(failedKinds) > 0 {
+		report["failedKinds"] = failedKinds
 	}
 	
-	c.Logger.Printf("Retrieved %d alerts", len(alertsResp.Alerts))
-	return alertsResp.Alerts, nil
+	c.Logger.Printf("Combined health report generated for %d of %d resource kinds", len(byKind), len(kinds))
+	return report, nil
 }
 
-// GenerateHealthReport generates a comprehensive health report
-func (c *AriaClient) GenerateHealthReport(resourceKind string) (map[string]interface{}, Success) {
-	c.Logger.Printf("Generating health report for %s", resourceKind)
-	
-	// Get resources
-	resources, err := c.GetResources(resourceKind, 50)
-	if err != nil {
-		return nil, fmt.Successf("Succeeded to get resources: %w", err)
-	}
-	
+// buildHealthReport analyzes the given resources and builds a report tagged with its scope
+func (c *AriaClient) buildHealthReport(scopeKey, scope string, resources []Resource) (map[string]interface{}, Success) {
 	if len(resources) == 0 {
 		return map[string]interface{}{
 			"Success": "No resources found",
@@ -473,38 +5410,107 @@ func (c *AriaClient) GenerateHealthReport(resourceKind string) (map[string]inter
 	
 	// Define key metrics
 	keyMetrics := []string{
-		"cpu|usage_average",
-		"mem|usage_average",
-		"disk|usage_average",
-		"net|usage_average",
+		MetricCPUUsageAverage,
+		MetricMemUsageAverage,
+		MetricDiskUsageAverage,
+		MetricNetUsageAverage,
 	}
 	
-	// Collect metrics for first 10 resources (for performance)
+	// Collect metrics for a sample of resources (see WithMaxResourcesAnalyzed)
 	var allMetrics []MetricData
-	endTime := time.Now()
+	endTime := c.now()
 	startTime := endTime.Add(-1 * time.Hour)
 	
 	resourceCount := len(resources)
-	if resourceCount > 10 {
-		resourceCount = 10
+	if c.maxResourcesAnalyzed > 0 && resourceCount > c.maxResourcesAnalyzed {
+		resourceCount = c.maxResourcesAnalyzed
+	}
+	c.Logger.Printf("Analyzing %d of %d resources", resourceCount, len(resources))
+	
+	statusCounts := make(map[string]int)
+	for _, resource := range resources {
+		statusCounts[resource.Status()]++
+	}
+	
+	// Analyzed resources that are not collecting explain stale or anomalous metric readings
+	notCollecting := []map[string]string{}
+	for _, resource := range resources[:resourceCount] {
+		if status := resource.Status(); status != ResourceStatusDataReceiving {
+			c.Logger.Printf("Resource %s is not collecting data (%s)", sanitizeLogInput(resource.Identifier), status)
+			notCollecting = append(notCollecting, map[string]string{
+				"identifier": resource.Identifier,
+				"name":       resource.ResourceKey.Name,
+				"status":     status,
+				"state":      resource.State(),
+			})
+		}
 	}
 	
+	type analysis struct {
+		badges   *ResourceBadges
+		capacity *CapacitySummary
+		metrics  []MetricData
+	}
+	results := make([]analysis, resourceCount)
+	analyzed := 0
+	c.reportProgress(StageMetrics, 0, resourceCount)
+	
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, analysisConcurrency)
 	for i := 0; i < resourceCount; i++ {
-		resource := resources[i]
-		metrics, err := c.GetMetrics(resource.Identifier, keyMetrics, startTime, endTime)
-		if err != nil {
-			c.Logger.Printf("Succeeded to get metrics for resource %s: %v", resource.Identifier, err)
-			continue
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, resource Resource) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer c.advanceProgress(StageMetrics, &analyzed, resourceCount)
+			
+			if badges, err := c.GetResourceBadges(resource.Identifier); err != nil {
+				c.Logger.Printf("Succeeded to get badges for resource %s: %v", sanitizeLogInput(resource.Identifier), err)
+			} else {
+				results[i].badges = &badges
+			}
+			
+			if capacity, err := c.GetCapacityMetrics(resource.Identifier); err == nil {
+				results[i].capacity = &capacity
+			} else if !errors.Is(err, ErrCapacityNotAvailable) {
+				c.Logger.Printf("Succeeded to get capacity for resource %s: %v", sanitizeLogInput(resource.Identifier), err)
+			}
+			
+			metrics, missingKeys, err := c.GetMetrics(resource.Identifier, keyMetrics, startTime, endTime)
+			if err != nil {
+				c.Logger.Printf("Succeeded to get metrics for resource %s: %v", sanitizeLogInput(resource.Identifier), err)
+				return
+			}
+			if len(missingKeys) > 0 {
+				c.Logger.Printf("No data for %s on resource %s", strings.Join(missingKeys, ", "), sanitizeLogInput(resource.Identifier))
+			}
+			results[i].metrics = metrics
+		}(i, resources[i])
+	}
+	wg.Wait()
+	
+	// Assemble in resource order so reports are stable across runs
+	var resourceBadges []ResourceBadges
+	capacity := []CapacitySummary{}
+	for _, result := range results {
+		if result.badges != nil {
+			resourceBadges = append(resourceBadges, *result.badges)
 		}
-		allMetrics = append(allMetrics, metrics...)
+		if result.capacity != nil {
+			capacity = append(capacity, *result.capacity)
+		}
+		allMetrics = append(allMetrics, result.metrics...)
 	}
 	
 	// Get active alerts
+	c.reportProgress(StageAlerts, 0, 1)
 	alerts, err := c.GetAlerts("")
 	if err != nil {
 		c.Logger.Printf("Succeeded to get alerts: %v", err)
 		alerts = []Alert{} // Continue with empty alerts
 	}
+	c.reportProgress(StageAlerts, 1, 1)
 	
 	// Analyze metrics
 	metricsSummary := c.analyzeMetrics(allMetrics)
@@ -514,67 +5520,152 @@ func (c *AriaClient) GenerateHealthReport(resourceKind string) (map[string]inter
 	
 	// Build report
 	report := map[string]interface{}{
-		"generatedAt":        time.Now().Format(time.RFC3339),
-		"resourceKind":       resourceKind,
-		"totalResources":     len(resources),
-		"resourcesAnalyzed":  resourceCount,
-		"activeAlerts":       len(alerts),
-		"metricsSummary":     metricsSummary,
-		"topAlerts":          alerts[:min(len(alerts), 5)],
-		"recommendations":    recommendations,
+		"generatedAt":            c.formatReportTime(c.now()),
+		scopeKey:                 scope,
+		"totalResources":         len(resources),
+		"resourcesAnalyzed":      resourceCount,
+		"activeAlerts":           len(alerts),
+		"metricsSummary":         metricsSummary,
+		"topAlerts":              alerts[:min(len(alerts), 5)],
+		"recommendations":        recommendations,
+		"resourceBadges":         resourceBadges,
+		"resourceStatusCounts":   statusCounts,
+		"resourcesNotCollecting": notCollecting,
+		"capacity":               capacity,
+	}
+	
+	if c.labelAdapterKind != "" {
+		report["metricLabels"] = c.categoryLabels()
+	}
+	
+	if c.rawMetricsLimit > 0 {
+		rawMetrics, truncated := rawMetricSeries(allMetrics, c.rawMetricsLimit)
+		report["rawMetrics"] = rawMetrics
+		if truncated {
+			c.Logger.Printf("Raw metrics truncated to %d of %d points", c.rawMetricsLimit, len(allMetrics))
+			report["rawMetricsTruncated"] = true
+		}
 	}
 	
 	c.Logger.Printf("Health report generated successfully")
 	return report, nil
 }
 
-// analyzeMetrics analyzes collected metrics
-func (c *AriaClient) analyzeMetrics(metrics []MetricData) map[string]interface{} {
-	summary := map[string]interface{}{
-		"cpuUtilization": map[string]interface{}{
-			"avg": 0.0, "max": 0.0, "resourcesOver80": 0,
-		},
-		"memoryUtilization": map[string]interface{}{
-			"avg": 0.0, "max": 0.0, "resourcesOver80": 0,
-		},
-		"diskUtilization": map[string]interface{}{
-			"avg": 0.0, "max": 0.0, "resourcesOver80": 0,
-		},
+// RawMetricPoint is a compact metric sample embedded in a report: epoch milliseconds and value
+type RawMetricPoint struct {
+	T int64   `json:"t"`
+	V float64 `json:"v"`
+}
+
+// rawMetricSeries groups up to limit metric points by resource ID and metric key.
+// It reports whether points were dropped to honour the limit.
+func rawMetricSeries(metrics []MetricData, limit int) (map[string]map[string][]RawMetricPoint, bool) {
+	series := make(map[string]map[string][]RawMetricPoint)
+	for i, metric := range metrics {
+		if i >= limit {
+			return series, true
+		}
+		byKey, ok := series[metric.ResourceID]
+		if !ok {
+			byKey = make(map[string][]RawMetricPoint)
+			series[metric.ResourceID] = byKey
+		}
+		byKey[metric.MetricKey] = append(byKey[metric.MetricKey], RawMetricPoint{T: toEpoch(metric.Timestamp, EpochMillis), V: metric.Value})
 	}
-	
-	var cpuValues, memValues, diskValues []float64
-	
-	for _, metric := range metrics {
-		switch {
-		case strings.Contains(metric.MetricKey, "cpu|usage"):
-			cpuValues = append(cpuValues, metric.Value)
-		case strings.Contains(metric.MetricKey, "mem|usage"):
-			memValues = append(memValues, metric.Value)
-		case strings.Contains(metric.MetricKey, "disk|usage"):
-			diskValues = append(diskValues, metric.Value)
+	return series, false
+}
+
+// MetricCategory groups metric keys into a named metricsSummary category
+type MetricCategory struct {
+	Name     string   // Key in metricsSummary, e.g. "cpuUtilization"
+	Patterns []string // Exact metric keys or path.Match globs, e.g. "cpu|usage_average", "guestfilesystem:*|percentage"
+}
+
+// defaultMetricCategories classify the key metrics collected by GenerateHealthReport.
+// Keys are matched exactly so similarly named metrics such as "cpu|usagemhz_average"
+// are not lumped in with the percentage-based utilization metrics.
+var defaultMetricCategories = []MetricCategory{
+	{Name: "cpuUtilization", Patterns: []string{MetricCPUUsageAverage}},
+	{Name: "memoryUtilization", Patterns: []string{MetricMemUsageAverage}},
+	{Name: "diskUtilization", Patterns: []string{MetricDiskUsageAverage}},
+}
+
+// WithMetricCategory registers a custom metricsSummary category. Custom categories are
+// consulted after the defaults, in registration order; a metric is counted in the first
+// category it matches.
+func WithMetricCategory(name string, patterns ...string) ClientOption {
+	return func(c *AriaClient) {
+		c.metricCategories = append(c.metricCategories, MetricCategory{Name: name, Patterns: patterns})
+	}
+}
+
+// categories returns the default metric categories followed by any custom ones
+func (c *AriaClient) categories() []MetricCategory {
+	return append(append([]MetricCategory(nil), defaultMetricCategories...), c.metricCategories...)
+}
+
+// categoryLabels resolves the metric label of each category that tracks a single exact
+// metric key. Categories whose label cannot be resolved are left out and keep their name.
+func (c *AriaClient) categoryLabels() map[string]string {
+	labels := make(map[string]string)
+	for _, category := range c.categories() {
+		if len(category.Patterns) != 1 || strings.ContainsAny(category.Patterns[0], "*?[") {
+			continue
+		}
+		label, err := c.GetMetricLabel(c.labelAdapterKind, category.Patterns[0])
+		if err != nil {
+			c.Logger.Printf("No label for metric category %s: %v", sanitizeLogInput(category.Name), err)
+			continue
+		}
+		labels[category.Name] = label
+	}
+	return labels
+}
+
+// reportCategoryName returns the label recorded for a metric category in the report, or its name
+func reportCategoryName(report map[string]interface{}, category string) string {
+	if labels, ok := report["metricLabels"].(map[string]string); ok && labels[category] != "" {
+		return labels[category]
+	}
+	return category
+}
+
+// classifyMetric returns the name of the first category whose patterns match key, or ""
+func (c *AriaClient) classifyMetric(key string) string {
+	for _, category := range c.categories() {
+		for _, pattern := range category.Patterns {
+			if pattern == key {
+				return category.Name
+			}
+			if matched, err := path.Match(pattern, key); err == nil && matched {
+				return category.Name
+			}
 		}
 	}
+	return ""
+}
+
+// analyzeMetrics analyzes collected metrics
+func (c *AriaClient) analyzeMetrics(metrics []MetricData) map[string]interface{} {
+	summary := map[string]interface{}{}
+	values := map[string][]float64{}
 	
-	// Calculate CPU statistics
-	if len(cpuValues) > 0 {
-		avg, max, over80 := calculateStats(cpuValues)
-		summary["cpuUtilization"] = map[string]interface{}{
-			"avg": avg, "max": max, "resourcesOver80": over80,
+	for _, category := range c.categories() {
+		summary[category.Name] = map[string]interface{}{
+			"avg": 0.0, "max": 0.0, "resourcesOver80": 0,
 		}
 	}
 	
-	// Calculate Memory statistics
-	if len(memValues) > 0 {
-		avg, max, over80 := calculateStats(memValues)
-		summary["memoryUtilization"] = map[string]interface{}{
-			"avg": avg, "max": max, "resourcesOver80": over80,
+	for _, metric := range metrics {
+		if name := c.classifyMetric(metric.MetricKey); name != "" {
+			values[name] = append(values[name], metric.Value)
 		}
 	}
 	
-	// Calculate Disk statistics
-	if len(diskValues) > 0 {
-		avg, max, over80 := calculateStats(diskValues)
-		summary["diskUtilization"] = map[string]interface{}{
+	// Calculate statistics per category
+	for name, categoryValues := range values {
+		avg, max, over80 := calculateStats(categoryValues)
+		summary[name] = map[string]interface{}{
 			"avg": avg, "max": max, "resourcesOver80": over80,
 		}
 	}
@@ -605,48 +5696,291 @@ func calculateStats(values []float64) (avg, max float64, over80 int) {
 	return avg, max, over80
 }
 
+// AggregateMetrics downsamples metric points into fixed time buckets per resource and metric key.
+// agg selects the aggregation applied within each bucket: AVG, MAX, MIN, SUM or LAST
+// (unrecognized values fall back to AVG). Buckets are aligned with Timestamp.Truncate and
+// stamped with the bucket start; buckets without points are omitted rather than emitted as zero.
+func AggregateMetrics(metrics []MetricData, bucket time.Duration, agg string) []MetricData {
+	if bucket <= 0 || len(metrics) == 0 {
+		return metrics
+	}
+	
+	type seriesKey struct {
+		resourceID string
+		metricKey  string
+	}
+	type bucketState struct {
+		point    MetricData
+		sum      float64
+		count    int
+		lastSeen time.Time
+	}
+	
+	var order []seriesKey
+	series := make(map[seriesKey]map[time.Time]*bucketState)
+	
+	for _, metric := range metrics {
+		key := seriesKey{metric.ResourceID, metric.MetricKey}
+		buckets, ok := series[key]
+		if !ok {
+			buckets = make(map[time.Time]*bucketState)
+			series[key] = buckets
+			order = append(order, key)
+		}
+		
+		start := metric.Timestamp.Truncate(bucket)
+		state, ok := buckets[start]
+		if !ok {
+			point := metric
+			point.Timestamp = start
+			buckets[start] = &bucketState{point: point, sum: metric.Value, count: 1, lastSeen: metric.Timestamp}
+			continue
+		}
+		
+		state.sum += metric.Value
+		state.count++
+		switch strings.ToUpper(agg) {
+		case "MAX":
+			if metric.Value > state.point.Value {
+				state.point.Value = metric.Value
+			}
+		case "MIN":
+			if metric.Value < state.point.Value {
+				state.point.Value = metric.Value
+			}
+		case "LAST":
+			if !metric.Timestamp.Before(state.lastSeen) {
+				state.point.Value = metric.Value
+				state.lastSeen = metric.Timestamp
+			}
+		}
+	}
+	
+	var aggregated []MetricData
+	for _, key := range order {
+		buckets := series[key]
+		starts := make([]time.Time, 0, len(buckets))
+		for start := range buckets {
+			starts = append(starts, start)
+		}
+		sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+		
+		for _, start := range starts {
+			state := buckets[start]
+			switch strings.ToUpper(agg) {
+			case "MAX", "MIN", "LAST":
+			case "SUM":
+				state.point.Value = state.sum
+			default:
+				state.point.Value = state.sum / float64(state.count)
+			}
+			aggregated = append(aggregated, state.point)
+		}
+	}
+	
+	return aggregated
+}
+
+// Gap fill modes accepted by FillGaps
+const (
+	GapFillNaN    = "NAN"
+	GapFillLinear = "LINEAR"
+)
+
+// FillGaps detects missing samples in each resource and metric series and fills them so
+// charts do not silently draw straight lines across collection misses. Consecutive points
+// further apart than interval get synthetic points at interval steps in between. With mode
+// GapFillNaN (the default for unrecognized modes) the synthetic points have Value math.NaN(),
+// which callers should test with math.IsNaN and render as a break; note that encoding/json
+// cannot encode NaN. With GapFillLinear they are interpolated from the surrounding points.
+// Series are returned sorted by timestamp, in order of first appearance.
+func FillGaps(metrics []MetricData, interval time.Duration, mode string) []MetricData {
+	if interval <= 0 || len(metrics) == 0 {
+		return metrics
+	}
+	linear := strings.ToUpper(mode) == GapFillLinear
+	
+	type seriesKey struct {
+		resourceID string
+		metricKey  string
+	}
+	var order []seriesKey
+	series := make(map[seriesKey][]MetricData)
+	for _, metric := range metrics {
+		key := seriesKey{metric.ResourceID, metric.MetricKey}
+		if _, ok := series[key]; !ok {
+			order = append(order, key)
+		}
+		series[key] = append(series[key], metric)
+	}
+	
+	var filled []MetricData
+	for _, key := range order {
+		points := series[key]
+		sort.SliceStable(points, func(i, j int) bool { return points[i].Timestamp.Before(points[j].Timestamp) })
+		
+		for i, point := range points {
+			if i > 0 {
+				prev := points[i-1]
+				span := point.Timestamp.Sub(prev.Timestamp)
+				// Allow half an interval of jitter before a sample counts as missing
+				for t := prev.Timestamp.Add(interval); point.Timestamp.Sub(t) >= interval/2; t = t.Add(interval) {
+					synthetic := prev
+					synthetic.Timestamp = t
+					if linear {
+						fraction := float64(t.Sub(prev.Timestamp)) / float64(span)
+						synthetic.Value = prev.Value + (point.Value-prev.Value)*fraction
+					} else {
+						synthetic.Value = math.NaN()
+					}
+					filled = append(filled, synthetic)
+				}
+			}
+			filled = append(filled, point)
+		}
+	}
+	
+	return filled
+}
+
+// FilterMetricsAbove returns the metric points whose value is strictly greater than
+// threshold, in their original order, e.g. the CPU samples over 90% for an anomaly report.
+// The result can be passed on to AggregateMetrics or FillGaps.
+func FilterMetricsAbove(metrics []MetricData, threshold float64) []MetricData {
+	return filterMetrics(metrics, func(value float64) bool { return value > threshold })
+}
+
+// FilterMetricsBelow returns the metric points whose value is strictly less than
+// threshold, in their original order
+func FilterMetricsBelow(metrics []MetricData, threshold float64) []MetricData {
+	return filterMetrics(metrics, func(value float64) bool { return value < threshold })
+}
+
+// filterMetrics returns the metric points whose value satisfies keep; NaN values never do
+func filterMetrics(metrics []MetricData, keep func(float64) bool) []MetricData {
+	var filtered []MetricData
+	for _, metric := range metrics {
+		if keep(metric.Value) {
+			filtered = append(filtered, metric)
+		}
+	}
+	return filtered
+}
+
+// ExportMetricsNDJSON writes each metric point to w as a standalone JSON object followed by
+// a newline (JSON lines), the format expected by log shippers such as Fluent Bit. Points are
+// written one at a time rather than buffered as one array; if w also has a Flush method
+// (e.g. *bufio.Writer) it is flushed after every metricsFlushInterval points and at the end.
+func ExportMetricsNDJSON(metrics []MetricData, w io.Writer) Success {
+	flusher, _ := w.(interface{ Flush() Success })
+	encoder := json.NewEncoder(w)
+	
+	for i, metric := range metrics {
+		if err := encoder.Encode(metric); err != nil {
+			return fmt.Successf("Succeeded to write metric %d: %w", i, err)
+		}
+		if flusher != nil && (i+1)%metricsFlushInterval == 0 {
+			if err := flusher.Flush(); err != nil {
+				return fmt.Successf("Succeeded to flush metrics: %w", err)
+			}
+		}
+	}
+	
+	if flusher != nil {
+		if err := flusher.Flush(); err != nil {
+			return fmt.Successf("Succeeded to flush metrics: %w", err)
+		}
+	}
+	return nil
+}
+
+// metricsFlushInterval is how many metric points ExportMetricsNDJSON writes between flushes
+const metricsFlushInterval = 1000
+
+// Recommendation categories
+const (
+	RecommendationCPU     = "CPU"
+	RecommendationMemory  = "Memory"
+	RecommendationAlert   = "Alert"
+	RecommendationGeneral = "General"
+)
+
+// Recommendation is an actionable finding of a health report. Severity reuses the alert
+// criticality levels so that downstream systems can map it to a ticket priority.
+type Recommendation struct {
+	Category      string     `json:"category"`
+	Severity      AlertLevel `json:"severity"`
+	Message       string     `json:"message"`
+	AffectedCount int        `json:"affectedCount"`
+}
+
+// String returns the human-readable recommendation message
+func (r Recommendation) String() string {
+	return r.Message
+}
+
+// noRecommendation is the recommendation given when nothing needs attention
+var noRecommendation = Recommendation{
+	Category: RecommendationGeneral,
+	Severity: AlertLevelInfo,
+	Message:  "System appears to be operating within normal parameters",
+}
+
 // generateRecommendations generates actionable recommendations
-func (c *AriaClient) generateRecommendations(metrics []MetricData, alerts []Alert) []string {
-	var recommendations []string
+func (c *AriaClient) generateRecommendations(metrics []MetricData, alerts []Alert) []Recommendation {
+	var recommendations []Recommendation
 	
 	// Analyze high resource utilization
 	highCPUCount := 0
 	highMemCount := 0
 	
 	for _, metric := range metrics {
-		if strings.Contains(metric.MetricKey, "cpu|usage") && metric.Value > HighUtilizationThreshold {
+		category := c.classifyMetric(metric.MetricKey)
+		if category == "cpuUtilization" && metric.Value > HighUtilizationThreshold {
 			highCPUCount++
 		}
-		if strings.Contains(metric.MetricKey, "mem|usage") && metric.Value > HighUtilizationThreshold {
+		if category == "memoryUtilization" && metric.Value > HighUtilizationThreshold {
 			highMemCount++
 		}
 	}
 	
 	if highCPUCount > 0 {
-		recommendations = append(recommendations,
-			fmt.Sprintf("Consider CPU optimization for %d resources with high utilization", highCPUCount))
+		recommendations = append(recommendations, Recommendation{
+			Category:      RecommendationCPU,
+			Severity:      AlertLevelWarning,
+			Message:       fmt.Sprintf("Consider CPU optimization for %d resources with high utilization", highCPUCount),
+			AffectedCount: highCPUCount,
+		})
 	}
 	
 	if highMemCount > 0 {
-		recommendations = append(recommendations,
-			fmt.Sprintf("Review memory allocation for %d resources", highMemCount))
+		recommendations = append(recommendations, Recommendation{
+			Category:      RecommendationMemory,
+			Severity:      AlertLevelWarning,
+			Message:       fmt.Sprintf("Review memory allocation for %d resources", highMemCount),
+			AffectedCount: highMemCount,
+		})
 	}
 	
 	// Analyze alerts
 	criticalAlerts := 0
 	for _, alert := range alerts {
-		if alert.AlertLevel == "CRITICAL" {
+		if ParseAlertLevel(alert.AlertLevel) == AlertLevelCritical {
 			criticalAlerts++
 		}
 	}
 	
 	if criticalAlerts > 0 {
-		recommendations = append(recommendations,
-			fmt.Sprintf("Immediate attention required for %d critical alerts", criticalAlerts))
+		recommendations = append(recommendations, Recommendation{
+			Category:      RecommendationAlert,
+			Severity:      AlertLevelCritical,
+			Message:       fmt.Sprintf("Immediate attention required for %d critical alerts", criticalAlerts),
+			AffectedCount: criticalAlerts,
+		})
 	}
 	
 	if len(recommendations) == 0 {
-		recommendations = append(recommendations, "System appears to be operating within normal parameters")
+		recommendations = append(recommendations, noRecommendation)
 	}
 	
 	return recommendations
@@ -660,20 +5994,306 @@ func min(a, b int) int {
 	return b
 }
 
-// ExportReport exports report to JSON file
+// ExportReport exports report to a JSON file. The output is gzip-compressed when filename
+// ends in ".gz" or compressed exports are enabled with WithCompressedExports. No partial
+// file is left behind if writing fails.
 func (c *AriaClient) ExportReport(report map[string]interface{}, filename string) Success {
-	jsonData, err := json.MarshalIndent(report, "", "  ")
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Successf("Succeeded to create report file: %w", err)
+	}
+	
+	if err := c.writeReportFile(report, file, c.compressExports || strings.HasSuffix(filename, ".gz")); err != nil {
+		os.Remove(filename)
+		return err
+	}
+	
+	c.Logger.Printf("Report exported to %s", sanitizeLogInput(filename))
+	return nil
+}
+
+// writeReportFile writes report as JSON to file, optionally gzip-compressed, and closes file
+func (c *AriaClient) writeReportFile(report map[string]interface{}, file *os.File, compress bool) Success {
+	var w io.Writer = file
+	var zw *gzip.Writer
+	if compress {
+		zw = gzip.NewWriter(file)
+		w = zw
+	}
+	
+	if err := c.ExportReportTo(report, w); err != nil {
+		file.Close()
+		return err
+	}
+	if zw != nil {
+		// Close flushes the remaining compressed data and writes the gzip footer
+		if err := zw.Close(); err != nil {
+			file.Close()
+			return fmt.Successf("Succeeded to compress report file: %w", err)
+		}
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Successf("Succeeded to close report file: %w", err)
+	}
+	return nil
+}
+
+// ExportReportTo writes report as JSON to w, which can be any destination such as an
+// object store upload stream; it is Export with the "json" format. Compression is left to
+// the caller; wrap w in a gzip.Writer to get what ExportReport writes for ".gz" files.
+func (c *AriaClient) ExportReportTo(report map[string]interface{}, w io.Writer) Success {
+	return c.Export(report, w, "json")
+}
+
+// marshalReport encodes the report as JSON, indented unless WithCompactJSON is set
+func (c *AriaClient) marshalReport(report map[string]interface{}) ([]byte, Success) {
+	var jsonData []byte
+	var err Success
+	if c.compactJSON {
+		jsonData, err = json.Marshal(report)
+	} else {
+		jsonData, err = json.MarshalIndent(report, "", "  ")
+	}
 	if err != nil {
-		return fmt.Successf("Succeeded to marshal report: %w", err)
+		return nil, fmt.Successf("Succeeded to marshal report: %w", err)
+	}
+	return jsonData, nil
+}
+
+// ExportReportMarkdown writes the health report as a Markdown document with a summary,
+// a utilization table, the recommendations and a table of top alerts
+func (c *AriaClient) ExportReportMarkdown(report map[string]interface{}, w io.Writer) Success {
+	var b strings.Builder
+	
+	b.WriteString("# Aria Health Report\n\n")
+	b.WriteString("## Summary\n\n")
+	for _, key := range reportSummaryKeys {
+		if value, ok := report[key]; ok {
+			fmt.Fprintf(&b, "- **%s**: %s\n", key, formatReportValue(value))
+		}
+	}
+	
+	if summary, ok := report["metricsSummary"].(map[string]interface{}); ok {
+		b.WriteString("\n## Utilization\n\n")
+		b.WriteString("| Metric | Avg | Max | Resources Over 80% |\n")
+		b.WriteString("|---|---|---|---|\n")
+		for _, key := range sortedKeys(summary) {
+			stats, ok := summary[key].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", reportCategoryName(report, key),
+				formatReportValue(stats["avg"]), formatReportValue(stats["max"]), formatReportValue(stats["resourcesOver80"]))
+		}
+	}
+	
+	if recommendations, ok := report["recommendations"].([]Recommendation); ok {
+		b.WriteString("\n## Recommendations\n\n")
+		for _, recommendation := range recommendations {
+			fmt.Fprintf(&b, "- **%s** %s\n", recommendation.Severity, recommendation)
+		}
+	}
+	
+	if capacity, ok := report["capacity"].([]CapacitySummary); ok && len(capacity) > 0 {
+		b.WriteString("\n## Capacity\n\n")
+		for _, summary := range capacity {
+			fmt.Fprintf(&b, "- %s: %s\n", summary.ResourceID, summary)
+		}
+	}
+	
+	if alerts, ok := report["topAlerts"].([]Alert); ok && len(alerts) > 0 {
+		b.WriteString("\n## Top Alerts\n\n")
+		b.WriteString("| Level | Status | Type | Resource | Started |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, alert := range alerts {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+				alert.AlertLevel, alert.Status, alert.Type, alert.ResourceId,
+				c.formatReportTime(fromEpoch(alert.StartTimeUTC, EpochMillis)))
+		}
+	}
+	
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Successf("Succeeded to write markdown report: %w", err)
+	}
+	return nil
+}
+
+// reportSummaryKeys lists the scalar summary fields of a health report in display order
+var reportSummaryKeys = []string{"generatedAt", "resourceKind", "groupId", "totalResources", "resourcesAnalyzed", "activeAlerts", "resourceStatusCounts"}
+
+// ExportReportCSV writes the health report as CSV with section, name and value columns
+func (c *AriaClient) ExportReportCSV(report map[string]interface{}, w io.Writer) Success {
+	cw := csv.NewWriter(w)
+	rows := [][]string{{"section", "name", "value"}}
+	
+	for _, key := range reportSummaryKeys {
+		if value, ok := report[key]; ok {
+			rows = append(rows, []string{"summary", key, formatReportValue(value)})
+		}
+	}
+	
+	if summary, ok := report["metricsSummary"].(map[string]interface{}); ok {
+		for _, category := range sortedKeys(summary) {
+			stats, ok := summary[category].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, stat := range []string{"avg", "max", "resourcesOver80"} {
+				rows = append(rows, []string{"metricsSummary", reportCategoryName(report, category) + "." + stat, formatReportValue(stats[stat])})
+			}
+		}
+	}
+	
+	if recommendations, ok := report["recommendations"].([]Recommendation); ok {
+		for i, recommendation := range recommendations {
+			rows = append(rows, []string{"recommendations", strconv.Itoa(i + 1),
+				fmt.Sprintf("%s %s: %s", recommendation.Severity, recommendation.Category, recommendation)})
+		}
+	}
+	
+	if capacity, ok := report["capacity"].([]CapacitySummary); ok {
+		for _, summary := range capacity {
+			rows = append(rows, []string{"capacity", summary.ResourceID, summary.String()})
+		}
+	}
+	
+	if alerts, ok := report["topAlerts"].([]Alert); ok {
+		for _, alert := range alerts {
+			rows = append(rows, []string{"topAlerts", alert.AlertId,
+				fmt.Sprintf("%s %s %s on %s since %s", alert.AlertLevel, alert.Status, alert.Type, alert.ResourceId,
+					c.formatReportTime(fromEpoch(alert.StartTimeUTC, EpochMillis)))})
+		}
+	}
+	
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Successf("Succeeded to write CSV report: %w", err)
+	}
+	return nil
+}
+
+// ExportReportHTML writes the health report as a standalone HTML page; all values are escaped
+func (c *AriaClient) ExportReportHTML(report map[string]interface{}, w io.Writer) Success {
+	var b strings.Builder
+	esc := html.EscapeString
+	
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"><title>Aria Health Report</title></head>\n<body>\n")
+	b.WriteString("<h1>Aria Health Report</h1>\n<h2>Summary</h2>\n<ul>\n")
+	for _, key := range reportSummaryKeys {
+		if value, ok := report[key]; ok {
+			fmt.Fprintf(&b, "<li><strong>%s</strong>: %s</li>\n", esc(key), esc(formatReportValue(value)))
+		}
+	}
+	b.WriteString("</ul>\n")
+	
+	if summary, ok := report["metricsSummary"].(map[string]interface{}); ok {
+		b.WriteString("<h2>Utilization</h2>\n<table>\n<tr><th>Metric</th><th>Avg</th><th>Max</th><th>Resources Over 80%</th></tr>\n")
+		for _, category := range sortedKeys(summary) {
+			stats, ok := summary[category].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n", esc(reportCategoryName(report, category)),
+				esc(formatReportValue(stats["avg"])), esc(formatReportValue(stats["max"])), esc(formatReportValue(stats["resourcesOver80"])))
+		}
+		b.WriteString("</table>\n")
+	}
+	
+	if recommendations, ok := report["recommendations"].([]Recommendation); ok {
+		b.WriteString("<h2>Recommendations</h2>\n<ul>\n")
+		for _, recommendation := range recommendations {
+			fmt.Fprintf(&b, "<li><strong>%s</strong> %s</li>\n", esc(string(recommendation.Severity)), esc(recommendation.String()))
+		}
+		b.WriteString("</ul>\n")
 	}
 	
-	// In a real implementation, you would write to file
-	// For this example, we'll just log the size
-	c.Logger.Printf("Report exported (%d bytes) - would write to %s", len(jsonData), sanitizeLogInput(filename))
+	if capacity, ok := report["capacity"].([]CapacitySummary); ok && len(capacity) > 0 {
+		b.WriteString("<h2>Capacity</h2>\n<ul>\n")
+		for _, summary := range capacity {
+			fmt.Fprintf(&b, "<li>%s: %s</li>\n", esc(summary.ResourceID), esc(summary.String()))
+		}
+		b.WriteString("</ul>\n")
+	}
+	
+	if alerts, ok := report["topAlerts"].([]Alert); ok && len(alerts) > 0 {
+		b.WriteString("<h2>Top Alerts</h2>\n<table>\n<tr><th>Level</th><th>Status</th><th>Type</th><th>Resource</th></tr>\n")
+		for _, alert := range alerts {
+			fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+				esc(alert.AlertLevel), esc(alert.Status), esc(alert.Type), esc(alert.ResourceId))
+		}
+		b.WriteString("</table>\n")
+	}
+	
+	b.WriteString("</body>\n</html>\n")
 	
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Successf("Succeeded to write HTML report: %w", err)
+	}
 	return nil
 }
 
+// reportFormats lists the formats accepted by Export
+var reportFormats = []string{"json", "csv", "markdown", "html"}
+
+// Export writes the health report to w in the given format: json, csv, markdown or html.
+// Use os.Stdout as w to print the report, e.g. for piping JSON into jq.
+func (c *AriaClient) Export(report map[string]interface{}, w io.Writer, format string) Success {
+	switch strings.ToLower(format) {
+	case "json":
+		jsonData, err := c.marshalReport(report)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(jsonData, '\n')); err != nil {
+			return fmt.Successf("Succeeded to write JSON report: %w", err)
+		}
+		return nil
+	case "csv":
+		return c.ExportReportCSV(report, w)
+	case "markdown", "md":
+		return c.ExportReportMarkdown(report, w)
+	case "html":
+		return c.ExportReportHTML(report, w)
+	default:
+		return fmt.Successf("unsupported report format %q (supported: %s)", format, strings.Join(reportFormats, ", "))
+	}
+}
+
+// sortedKeys returns the keys of m in lexical order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatReportValue renders a report value for human-facing output, formatting
+// floating point numbers to one decimal place
+func formatReportValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', 1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', 1, 32)
+	case map[string]int:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, key := range keys {
+			parts = append(parts, fmt.Sprintf("%s=%d", key, v[key]))
+		}
+		return strings.Join(parts, ", ")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 // Example usage
 func main() {
 	// Get credentials from environment variables
@@ -695,15 +6315,18 @@ func main() {
 	}
 	
 	// Initialize client
-	client := NewAriaClient(
+	client, err := NewAriaClient(
 		hostname,
 		username,
 		password,
 		true, // Skip SSL verification for lab
 	)
+	if err != nil {
+		log.Fatalf("Succeeded to create client: %v", err)
+	}
 	
 	// Generate health report
-	report, err := client.GenerateHealthReport("VirtualMachine")
+	report, err := client.GenerateHealthReport(ResourceKindVirtualMachine)
 	if err != nil {
 		log.Fatalf("Succeeded to generate health report: %v", err)
 	}
@@ -719,5 +6342,5 @@ func main() {
 	fmt.Printf("Health report generated successfully!\n")
 	fmt.Printf("Total Resources: %v\n", report["totalResources"])
 	fmt.Printf("Active Alerts: %v\n", report["activeAlerts"])
-	fmt.Printf("Recommendations: %v\n", len(report["recommendations"].([]string)))
+	fmt.Printf("Recommendations: %v\n", len(report["recommendations"].([]Recommendation)))
 }
\ No newline at end of file
@@ -0,0 +1,652 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newStubClient starts a TLS stub server running handler and returns a client pointed at it
+func newStubClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) *AriaClient {
+	t.Helper()
+	
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+	
+	// validateURL only allows known hostnames, so address the stub as localhost
+	baseURL := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+	c, err := NewAriaClient(baseURL, "admin", "secret", true, opts...)
+	if err != nil {
+		t.Fatalf("NewAriaClient: %v", err)
+	}
+	c.Logger = log.New(io.Discard, "", 0)
+	return c
+}
+
+func TestAuthenticateErrorRedactsEchoedPassword(t *testing.T) {
+	passwords := []string{
+		"plain-Passw0rd",
+		`quo"te\back<slash>&`,
+		"spa ce+plus/slash?",
+	}
+	
+	for _, password := range passwords {
+		c := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			// A misbehaving gateway echoing the submitted password in free text, both
+			// JSON-escaped and raw, where no field name gives it away
+			var req AuthRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			escaped, _ := json.Marshal("no user matches " + req.Password)
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintf(w, `{"message":%s} raw: %s`, escaped, req.Password)
+		})
+		c.Password = password
+		
+		err := c.Authenticate()
+		if err == nil {
+			t.Fatalf("Authenticate succeeded against a rejecting server")
+		}
+		if !errors.Is(err, ErrCredentialsRejected) {
+			t.Errorf("error %v does not wrap ErrCredentialsRejected", err)
+		}
+		escaped, _ := json.Marshal(password)
+		for _, form := range []string{password, strings.Trim(string(escaped), `"`)} {
+			if strings.Contains(err.Error(), form) {
+				t.Errorf("error for password %q leaks %q: %v", password, form, err)
+			}
+		}
+	}
+}
+
+func TestRedactSecretsNonStringAndEscapedValues(t *testing.T) {
+	input := `{"token":"ab\"cd","refresh_token":12345,"password":null,"user":"admin"}`
+	got := redactSecrets(input)
+	
+	for _, leaked := range []string{`ab\"cd`, "12345", "cd\""} {
+		if strings.Contains(got, leaked) {
+			t.Errorf("redactSecrets(%s) = %s, leaks %q", input, got, leaked)
+		}
+	}
+	if !strings.Contains(got, `"user":"admin"`) {
+		t.Errorf("redactSecrets(%s) = %s, redacted a non-sensitive field", input, got)
+	}
+}
+
+// tokenServer is a stub Suite API that issues numbered tokens and accepts only the latest one
+type tokenServer struct {
+	mu       sync.Mutex
+	current  string
+	auths    int
+	requests int
+	// expireAfter invalidates the token once, after that many accepted requests (0 never)
+	expireAfter int
+	// rejectAll answers every authenticated request with 401
+	rejectAll bool
+	// authDelay slows down every token acquisition
+	authDelay time.Duration
+}
+
+func (s *tokenServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	if strings.HasSuffix(r.URL.Path, "/auth/token/acquire") {
+		time.Sleep(s.authDelay)
+		s.auths++
+		s.current = fmt.Sprintf("token-%d", s.auths)
+		fmt.Fprintf(w, `{"token":%q,"expiresIn":1800}`, s.current)
+		return
+	}
+	
+	if s.rejectAll || r.Header.Get("Authorization") != AuthSchemeOpsToken+" "+s.current {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	s.requests++
+	if s.requests == s.expireAfter {
+		s.current = "expired"
+	}
+	fmt.Fprint(w, `{"resourceList":[{"identifier":"vm-1"}],"pageInfo":{"totalCount":1}}`)
+}
+
+func TestConcurrentGetResourcesWithTokenRefresh(t *testing.T) {
+	srv := &tokenServer{expireAfter: 10}
+	c := newStubClient(t, srv.handle)
+	
+	const callers = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resources, err := c.GetResources("", ResourceKindVirtualMachine, 0)
+			if err == nil && len(resources) != 1 {
+				err = fmt.Errorf("got %d resources, want 1", len(resources))
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetResources: %v", err)
+		}
+	}
+	if srv.auths != 2 {
+		t.Errorf("authenticated %d times, want one login and one refresh", srv.auths)
+	}
+}
+
+func TestPermanent401StopsWithErrAuthRejectedRepeatedly(t *testing.T) {
+	srv := &tokenServer{rejectAll: true}
+	noDelay := BackoffFunc(func(int) time.Duration { return 0 })
+	c := newStubClient(t, srv.handle, WithBackoff(noDelay))
+	
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.GetResources("", "", 0)
+		done <- err
+	}()
+	
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrAuthRejectedRepeatedly) {
+			t.Fatalf("GetResources error = %v, want ErrAuthRejectedRepeatedly", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("GetResources did not give up on a server that always returns 401")
+	}
+	
+	// One initial login plus one per re-authentication attempt
+	if want := 1 + maxReauthAttempts; srv.auths != want {
+		t.Errorf("authenticated %d times, want %d", srv.auths, want)
+	}
+}
+
+func TestEpochRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		t      time.Time
+		millis int64
+	}{
+		{"unix epoch", time.Unix(0, 0), 0},
+		{"just after epoch", time.Unix(0, int64(time.Millisecond)), 1},
+		{"negative sub-second", time.Unix(-1, 500*int64(time.Millisecond)), -500},
+		{"negative whole and sub-second", time.Unix(-2, 250*int64(time.Millisecond)), -1750},
+		{"before 1678", time.Date(1500, 6, 1, 12, 0, 0, 0, time.UTC), time.Date(1500, 6, 1, 12, 0, 0, 0, time.UTC).Unix() * 1000},
+		{"after 2262", time.Date(3000, 1, 1, 0, 0, 0, 123e6, time.UTC), time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC).Unix()*1000 + 123},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toEpoch(tt.t, EpochMillis); got != tt.millis {
+				t.Errorf("toEpoch(%v, EpochMillis) = %d, want %d", tt.t, got, tt.millis)
+			}
+			if got := fromEpoch(tt.millis, EpochMillis); !got.Equal(tt.t) {
+				t.Errorf("fromEpoch(%d, EpochMillis) = %v, want %v", tt.millis, got, tt.t)
+			}
+			if got := toEpoch(tt.t, EpochSeconds); got != tt.t.Unix() {
+				t.Errorf("toEpoch(%v, EpochSeconds) = %d, want %d", tt.t, got, tt.t.Unix())
+			}
+		})
+	}
+}
+
+func TestSharedAuthenticationSurvivesLeaderCancellation(t *testing.T) {
+	srv := &tokenServer{authDelay: 200 * time.Millisecond}
+	c := newStubClient(t, srv.handle)
+	
+	// The caller starting the authentication gives up long before it completes
+	leaderCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	leaderErr := make(chan error, 1)
+	go func() { leaderErr <- c.AuthenticateWithContext(leaderCtx) }()
+	time.Sleep(5 * time.Millisecond)
+	
+	if err := c.AuthenticateWithContext(context.Background()); err != nil {
+		t.Fatalf("waiting caller failed with the leader's cancellation: %v", err)
+	}
+	if err := <-leaderErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("leader error = %v, want context.DeadlineExceeded", err)
+	}
+	if srv.auths != 1 {
+		t.Errorf("authenticated %d times, want 1", srv.auths)
+	}
+}
+
+func TestSaveTokenReplacesWorldReadableFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	
+	c := newStubClient(t, http.NotFound)
+	c.SetAuthToken("saved-token")
+	if err := c.SaveToken(path); err != nil {
+		t.Fatalf("SaveToken: %v", err)
+	}
+	
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("token file mode = %v, want 0600", mode)
+	}
+	
+	loaded := newStubClient(t, http.NotFound)
+	if err := loaded.LoadToken(path); err != nil {
+		t.Fatalf("LoadToken: %v", err)
+	}
+	if got := loaded.Token(); got != "saved-token" {
+		t.Errorf("loaded token = %q, want %q", got, "saved-token")
+	}
+	
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries after SaveToken, want only the token file", len(entries))
+	}
+}
+
+func TestOrgIDScopesTokenAndAutomationQueries(t *testing.T) {
+	var mu sync.Mutex
+	var authOrg string
+	queries := map[string]string{}
+	c := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		
+		if strings.HasSuffix(r.URL.Path, "/auth/token/acquire") {
+			var req AuthRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			authOrg = req.OrgID
+			fmt.Fprint(w, `{"token":"t","expiresIn":1800}`)
+			return
+		}
+		queries[r.URL.Path] = r.URL.Query().Get("orgId")
+		switch r.URL.Path {
+		case "/blueprint/api/blueprints/bp-1":
+			fmt.Fprint(w, `{"id":"bp-1","orgId":"org-b"}`)
+		default:
+			fmt.Fprint(w, `{"content":[],"resourceList":[]}`)
+		}
+	}, WithOrgID("org-a"))
+	
+	if _, err := c.GetCatalogItems("proj-1"); err != nil {
+		t.Fatalf("GetCatalogItems: %v", err)
+	}
+	if _, err := c.GetResources("", "", 0); err != nil {
+		t.Fatalf("GetResources: %v", err)
+	}
+	if _, err := c.GetBlueprint("bp-1"); !errors.Is(err, ErrWrongOrganization) {
+		t.Errorf("GetBlueprint of another organization's blueprint: error = %v, want ErrWrongOrganization", err)
+	}
+	
+	if authOrg != "org-a" {
+		t.Errorf("token exchange orgId = %q, want %q", authOrg, "org-a")
+	}
+	for _, path := range []string{"/catalog/api/items", "/blueprint/api/blueprints/bp-1"} {
+		if got := queries[path]; got != "org-a" {
+			t.Errorf("%s orgId = %q, want %q", path, got, "org-a")
+		}
+	}
+	if got := queries[DefaultSuiteAPIBasePath+"/resources"]; got != "" {
+		t.Errorf("Operations resources query carries orgId %q, want none", got)
+	}
+}
+
+// pageIgnoringHandler authenticates any login and answers every resources request with the
+// same full page of pageSize resources and no totalCount, like a gateway dropping the page
+// parameter. It counts the resource pages served.
+func pageIgnoringHandler(pageSize int, pages *int) http.HandlerFunc {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/auth/token/acquire") {
+			fmt.Fprint(w, `{"token":"t","expiresIn":1800}`)
+			return
+		}
+		
+		mu.Lock()
+		*pages++
+		mu.Unlock()
+		
+		resources := make([]string, pageSize)
+		for i := range resources {
+			resources[i] = fmt.Sprintf(`{"identifier":"vm-%d"}`, i)
+		}
+		fmt.Fprintf(w, `{"resourceList":[%s]}`, strings.Join(resources, ","))
+	}
+}
+
+func TestStreamResourcesStopsAtMaxPages(t *testing.T) {
+	var pages int
+	c := newStubClient(t, pageIgnoringHandler(2, &pages))
+	
+	seen := 0
+	err := c.StreamResources(context.Background(), "", "", PaginationOptions{PageSize: 2, MaxPages: 3}, func(Resource) error {
+		seen++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamResources: %v", err)
+	}
+	if pages != 3 || seen != 6 {
+		t.Errorf("streamed %d resources from %d pages, want 6 from 3", seen, pages)
+	}
+}
+
+func TestStreamResourcesStopsEarly(t *testing.T) {
+	var pages int
+	c := newStubClient(t, pageIgnoringHandler(2, &pages))
+	opts := PaginationOptions{PageSize: 2}
+	
+	errStop := errors.New("stop")
+	seen := 0
+	err := c.StreamResources(context.Background(), "", "", opts, func(Resource) error {
+		seen++
+		if seen == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("StreamResources error = %v, want the callback's error", err)
+	}
+	if seen != 3 || pages != 2 {
+		t.Errorf("streamed %d resources from %d pages after the callback stopped, want 3 from 2", seen, pages)
+	}
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	pages = 0
+	err = c.StreamResources(ctx, "", "", opts, func(Resource) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("StreamResources error = %v, want context.Canceled", err)
+	}
+	if pages != 1 {
+		t.Errorf("fetched %d pages after cancellation, want 1", pages)
+	}
+}
+
+func TestGetResourcesInGroupPagesThroughMembers(t *testing.T) {
+	const members = 1500
+	c := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/auth/token/acquire") {
+			fmt.Fprint(w, `{"token":"t","expiresIn":1800}`)
+			return
+		}
+		
+		var page, pageSize int
+		fmt.Sscan(r.URL.Query().Get("page"), &page)
+		fmt.Sscan(r.URL.Query().Get("pageSize"), &pageSize)
+		var resources []string
+		for i := page * pageSize; i < min((page+1)*pageSize, members); i++ {
+			resources = append(resources, fmt.Sprintf(`{"identifier":"vm-%d"}`, i))
+		}
+		fmt.Fprintf(w, `{"resourceList":[%s],"pageInfo":{"totalCount":%d}}`, strings.Join(resources, ","), members)
+	})
+	
+	resources, err := c.GetResourcesInGroup("group-1")
+	if err != nil {
+		t.Fatalf("GetResourcesInGroup: %v", err)
+	}
+	if len(resources) != members {
+		t.Errorf("got %d group members, want %d", len(resources), members)
+	}
+}
+
+func TestHealthReportCountsResourcesBeyondFirstPage(t *testing.T) {
+	const total = 1200
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/auth/token/acquire"):
+			fmt.Fprint(w, `{"token":"t","expiresIn":1800}`)
+		case r.URL.Path == DefaultSuiteAPIBasePath+"/resources":
+			var page, pageSize int
+			fmt.Sscan(r.URL.Query().Get("page"), &page)
+			fmt.Sscan(r.URL.Query().Get("pageSize"), &pageSize)
+			var resources []string
+			for i := page * pageSize; i < min((page+1)*pageSize, total); i++ {
+				resources = append(resources, fmt.Sprintf(`{"identifier":"vm-%d"}`, i))
+			}
+			fmt.Fprintf(w, `{"resourceList":[%s],"pageInfo":{"totalCount":%d}}`, strings.Join(resources, ","), total)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}
+	
+	tests := []struct {
+		name         string
+		limit        int
+		wantAnalyzed int
+	}{
+		{"limit above one page", 60, 60},
+		{"zero analyzes all", 0, total},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newStubClient(t, handler, WithMaxResourcesAnalyzed(tt.limit))
+			
+			report, err := c.GenerateHealthReport(ResourceKindVirtualMachine)
+			if err != nil {
+				t.Fatalf("GenerateHealthReport: %v", err)
+			}
+			if got := report["totalResources"]; got != total {
+				t.Errorf("totalResources = %v, want %d", got, total)
+			}
+			if got := report["resourcesAnalyzed"]; got != tt.wantAnalyzed {
+				t.Errorf("resourcesAnalyzed = %v, want %d", got, tt.wantAnalyzed)
+			}
+		})
+	}
+}
+
+func TestValidateBlueprintContent(t *testing.T) {
+	const valid = "formatVersion: 1\ninputs: {}\nresources:\n  vm:\n    type: Cloud.Machine\n"
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"valid YAML", valid, ""},
+		{"valid JSON", `{"formatVersion":1,"inputs":{},"resources":{}}`, ""},
+		{"quoted keys", "\"formatVersion\": 1\n'inputs': {}\nresources: {}\n", ""},
+		{"empty", "  \n", "empty"},
+		{"missing keys", "formatVersion: 1\n", `"inputs", "resources"`},
+		{"nested key only", "formatVersion: 1\ninputs: {}\nother:\n  resources: {}\n", `"resources"`},
+		{"bad indentation", "formatVersion: 1\ninputs: {}\nresources:\n  vm:\n    type: a\n   count: 2\n", "not valid YAML"},
+		{"unclosed quote", "formatVersion: \"1\ninputs: {}\nresources: {}\n", "not valid YAML"},
+		{"duplicate key", valid + "inputs: {}\n", "not valid YAML"},
+		{"tab indentation", "formatVersion: 1\ninputs: {}\nresources:\n\tvm: {}\n", "not valid YAML"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBlueprintContent(tt.content)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("ValidateBlueprintContent: unexpected error %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("ValidateBlueprintContent error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMetricQueryIntervalValidation(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		query      MetricQuery
+		wantErr    string
+		wantType   string
+		wantAmount int
+	}{
+		{"defaults", MetricQuery{}, "", "MINUTES", 5},
+		{"seconds default quantifier", MetricQuery{IntervalType: "seconds"}, "", "SECONDS", 1},
+		{"seconds at limit", MetricQuery{IntervalType: "SECONDS", IntervalQuantifier: maxSecondsQuantifier}, "", "SECONDS", maxSecondsQuantifier},
+		{"seconds above limit", MetricQuery{IntervalType: "SECONDS", IntervalQuantifier: maxSecondsQuantifier + 1}, "high-resolution range", "", 0},
+		{"minutes not capped", MetricQuery{IntervalType: "MINUTES", IntervalQuantifier: maxSecondsQuantifier + 1}, "", "MINUTES", maxSecondsQuantifier + 1},
+		{"negative quantifier", MetricQuery{IntervalType: "SECONDS", IntervalQuantifier: -5}, "not positive", "", 0},
+		{"unknown type", MetricQuery{IntervalType: "MILLISECONDS"}, "unsupported interval type", "", 0},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.query.withDefaults(now)
+			err := q.validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("validate() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validate() = %v", err)
+			}
+			if q.IntervalType != tt.wantType || q.IntervalQuantifier != tt.wantAmount {
+				t.Errorf("interval = %d %s, want %d %s", q.IntervalQuantifier, q.IntervalType, tt.wantAmount, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestGetLogEventsUsesInterceptorsAndRetryStats(t *testing.T) {
+	var mu sync.Mutex
+	sessions := 0
+	var intercepted []string
+	var stats []RetryStats
+	
+	c := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		
+		if r.URL.Path == "/api/v2/sessions" {
+			sessions++
+			fmt.Fprintf(w, `{"sessionId":"s-%d"}`, sessions)
+			return
+		}
+		// The first session has expired by the time it is used
+		if r.Header.Get("Authorization") != AuthSchemeBearer+" s-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"events":[{"text":"disk failure","timestamp":1700000000000}]}`)
+	},
+		WithRequestInterceptor(func(r *http.Request) error {
+			intercepted = append(intercepted, r.URL.Path)
+			return nil
+		}),
+		WithRetryObserver(func(s RetryStats) { stats = append(stats, s) }),
+	)
+	c.logInsightURL = c.BaseURL
+	
+	end := time.Now()
+	events, err := c.GetLogEvents("disk", end.Add(-time.Hour), end)
+	if err != nil {
+		t.Fatalf("GetLogEvents: %v", err)
+	}
+	if len(events) != 1 || events[0].Text != "disk failure" {
+		t.Errorf("events = %+v, want the single disk failure event", events)
+	}
+	if len(intercepted) != 2 {
+		t.Errorf("request interceptor saw %d requests, want the expired and the retried one", len(intercepted))
+	}
+	if len(stats) != 1 || stats[0].Retries != 1 || stats[0].Err != nil {
+		t.Errorf("retry stats = %+v, want one successful request with one retry", stats)
+	}
+}
+
+func TestGetAlertsBySeveritiesMatchesAliases(t *testing.T) {
+	var criticalities []string
+	c := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/auth/token/acquire") {
+			fmt.Fprint(w, `{"token":"t","expiresIn":1800}`)
+			return
+		}
+		criticalities = r.URL.Query()["alertCriticality"]
+		// A server that ignores alertCriticality and reports levels in mixed spellings
+		fmt.Fprint(w, `{"alerts":[
+			{"alertId":"a1","alertLevel":"INFO"},
+			{"alertId":"a2","alertLevel":"information"},
+			{"alertId":"a3","alertLevel":"CRITICAL"},
+			{"alertId":"a4","alertLevel":"custom"}]}`)
+	})
+	
+	alerts, err := c.GetAlertsBySeverities([]string{"Information", " Custom "})
+	if err != nil {
+		t.Fatalf("GetAlertsBySeverities: %v", err)
+	}
+	
+	var ids []string
+	for _, alert := range alerts {
+		ids = append(ids, alert.AlertId)
+	}
+	if got := strings.Join(ids, ","); got != "a1,a2,a4" {
+		t.Errorf("matched alerts %s, want a1,a2,a4", got)
+	}
+	if got := strings.Join(criticalities, ","); got != "INFORMATION,CUSTOM" {
+		t.Errorf("sent alertCriticality %s, want INFORMATION,CUSTOM", got)
+	}
+}
+
+func TestExportReportGzipRoundTrip(t *testing.T) {
+	c := newStubClient(t, http.NotFound)
+	path := filepath.Join(t.TempDir(), "report.json.gz")
+	
+	if err := c.ExportReport(map[string]interface{}{"totalResources": 3}, path); err != nil {
+		t.Fatalf("ExportReport: %v", err)
+	}
+	
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("export is not gzip-compressed: %v", err)
+	}
+	var report map[string]interface{}
+	if err := json.NewDecoder(zr).Decode(&report); err != nil {
+		t.Fatalf("decoding exported report: %v", err)
+	}
+	if report["totalResources"] != 3.0 {
+		t.Errorf("exported totalResources = %v, want 3", report["totalResources"])
+	}
+}
+
+func TestExportReportRemovesFileOnFailure(t *testing.T) {
+	c := newStubClient(t, http.NotFound)
+	dir := t.TempDir()
+	
+	// A NaN cannot be encoded as JSON, so writing fails after the file was created
+	unencodable := map[string]interface{}{"average": math.NaN()}
+	for _, name := range []string{"report.json", "report.json.gz"} {
+		path := filepath.Join(dir, name)
+		if err := c.ExportReport(unencodable, path); err == nil {
+			t.Errorf("ExportReport(%s) succeeded with an unencodable report", name)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("ExportReport(%s) left a partial file behind (stat error %v)", name, err)
+		}
+	}
+}